@@ -134,7 +134,8 @@ var (
 	// LoggerFrom returns a logger with predefined values from a context.Context.
 	// The logger, when used with controllers, can be expected to contain basic information about the object
 	// that's being reconciled like:
-	// - `reconciler group` and `reconciler kind` coming from the For(...) object passed in when building a controller.
+	// - `controller` set to the name of the controller that is running the reconciliation.
+	// - `controllerGroup` and `controllerKind` coming from the For(...) object passed in when building a controller.
 	// - `name` and `namespace` injected from the reconciliation request.
 	//
 	// This is meant to be used with the context supplied in a struct that satisfies the Reconciler interface.
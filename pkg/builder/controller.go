@@ -58,6 +58,7 @@ type Builder struct {
 	globalPredicates []predicate.Predicate
 	ctrl             controller.Controller
 	ctrlOptions      controller.Options
+	log              logr.Logger
 	name             string
 }
 
@@ -94,6 +95,7 @@ func (blder *Builder) For(object client.Object, opts ...ForOption) *Builder {
 
 // OwnsInput represents the information set by Owns method.
 type OwnsInput struct {
+	matchEveryOwner  bool
 	object           client.Object
 	predicates       []predicate.Predicate
 	objectProjection objectProjection
@@ -102,6 +104,9 @@ type OwnsInput struct {
 // Owns defines types of Objects being *generated* by the ControllerManagedBy, and configures the ControllerManagedBy to respond to
 // create / delete / update events by *reconciling the owner object*.  This is the equivalent of calling
 // Watches(&source.Kind{Type: <ForType-forInput>}, &handler.EnqueueRequestForOwner{OwnerType: apiType, IsController: true}).
+//
+// With the MatchEveryOwner option, every owner reference to an object of the For type is
+// enqueued, not only the controller reference.
 func (blder *Builder) Owns(object client.Object, opts ...OwnsOption) *Builder {
 	input := OwnsInput{object: object}
 	for _, opt := range opts {
@@ -118,6 +123,7 @@ type WatchesInput struct {
 	eventhandler     handler.EventHandler
 	predicates       []predicate.Predicate
 	objectProjection objectProjection
+	raw              bool
 }
 
 // Watches exposes the lower-level ControllerManagedBy Watches functions through the builder.  Consider using
@@ -133,6 +139,23 @@ func (blder *Builder) Watches(src source.Source, eventhandler handler.EventHandl
 	return blder
 }
 
+// WatchesRawSource registers a pre-constructed source and event handler with the controller as-is.
+// Unlike Watches, the source is never projected or otherwise modified by the builder, which makes
+// it suitable for channel sources or sources backed by a different cluster.
+// Predicates set with WithEventFilter and the given WithPredicates options still apply.
+//
+// A controller built only from WatchesRawSource and Watches does not need For, but must then be
+// given a name with Named.
+func (blder *Builder) WatchesRawSource(src source.Source, eventhandler handler.EventHandler, opts ...WatchesOption) *Builder {
+	input := WatchesInput{src: src, eventhandler: eventhandler, raw: true}
+	for _, opt := range opts {
+		opt.ApplyToWatches(&input)
+	}
+
+	blder.watchesInput = append(blder.watchesInput, input)
+	return blder
+}
+
 // WithEventFilter sets the event filters, to filter which create/update/delete/generic events eventually
 // trigger reconciliations.  For example, filtering on whether the resource version has changed.
 // Given predicate is added for all watched objects.
@@ -143,6 +166,8 @@ func (blder *Builder) WithEventFilter(p predicate.Predicate) *Builder {
 }
 
 // WithOptions overrides the controller options use in doController. Defaults to empty.
+// A logger set with WithLogger takes precedence over the one of the options, regardless
+// of the order the methods are called in.
 func (blder *Builder) WithOptions(options controller.Options) *Builder {
 	blder.ctrlOptions = options
 	return blder
@@ -150,7 +175,7 @@ func (blder *Builder) WithOptions(options controller.Options) *Builder {
 
 // WithLogger overrides the controller options's logger used.
 func (blder *Builder) WithLogger(log logr.Logger) *Builder {
-	blder.ctrlOptions.Log = log
+	blder.log = log
 	return blder
 }
 
@@ -159,6 +184,8 @@ func (blder *Builder) WithLogger(log logr.Logger) *Builder {
 // (underscores and alphanumeric characters only).
 //
 // By default, controllers are named using the lowercase version of their kind.
+// As controller names must be unique, all but one of multiple controllers
+// reconciling the same kind in one process must be given a name explicitly.
 func (blder *Builder) Named(name string) *Builder {
 	blder.name = name
 	return blder
@@ -181,9 +208,15 @@ func (blder *Builder) Build(r reconcile.Reconciler) (controller.Controller, erro
 	if blder.forInput.err != nil {
 		return nil, blder.forInput.err
 	}
-	// Checking the reconcile type exist or not
+	// Checking the reconcile type exist or not. Controllers that only use
+	// Watches need a name instead, since it can't be derived from the For type.
 	if blder.forInput.object == nil {
-		return nil, fmt.Errorf("must provide an object for reconciliation")
+		if len(blder.ownsInput) > 0 || len(blder.watchesInput) == 0 {
+			return nil, fmt.Errorf("must provide an object for reconciliation")
+		}
+		if blder.name == "" {
+			return nil, fmt.Errorf("must provide a name with Named() when For() is not used")
+		}
 	}
 
 	// Set the ControllerManagedBy
@@ -218,15 +251,18 @@ func (blder *Builder) project(obj client.Object, proj objectProjection) (client.
 
 func (blder *Builder) doWatch() error {
 	// Reconcile type
-	typeForSrc, err := blder.project(blder.forInput.object, blder.forInput.objectProjection)
-	if err != nil {
-		return err
-	}
-	src := &source.Kind{Type: typeForSrc}
-	hdler := &handler.EnqueueRequestForObject{}
-	allPredicates := append(blder.globalPredicates, blder.forInput.predicates...)
-	if err := blder.ctrl.Watch(src, hdler, allPredicates...); err != nil {
-		return err
+	if blder.forInput.object != nil {
+		typeForSrc, err := blder.project(blder.forInput.object, blder.forInput.objectProjection)
+		if err != nil {
+			return err
+		}
+		src := &source.Kind{Type: typeForSrc}
+		hdler := &handler.EnqueueRequestForObject{}
+		allPredicates := append([]predicate.Predicate(nil), blder.globalPredicates...)
+		allPredicates = append(allPredicates, blder.forInput.predicates...)
+		if err := blder.ctrl.Watch(src, hdler, allPredicates...); err != nil {
+			return err
+		}
 	}
 
 	// Watches the managed types
@@ -238,7 +274,7 @@ func (blder *Builder) doWatch() error {
 		src := &source.Kind{Type: typeForSrc}
 		hdler := &handler.EnqueueRequestForOwner{
 			OwnerType:    blder.forInput.object,
-			IsController: true,
+			IsController: !own.matchEveryOwner,
 		}
 		allPredicates := append([]predicate.Predicate(nil), blder.globalPredicates...)
 		allPredicates = append(allPredicates, own.predicates...)
@@ -253,12 +289,19 @@ func (blder *Builder) doWatch() error {
 		allPredicates = append(allPredicates, w.predicates...)
 
 		// If the source of this watch is of type *source.Kind, project it.
-		if srckind, ok := w.src.(*source.Kind); ok {
+		// Raw sources are passed through untouched.
+		if w.raw {
+			if w.objectProjection != projectAsNormal {
+				return fmt.Errorf("projections like OnlyMetadata are not supported for sources passed to WatchesRawSource")
+			}
+		} else if srckind, ok := w.src.(*source.Kind); ok {
 			typeForSrc, err := blder.project(srckind.Type, w.objectProjection)
 			if err != nil {
 				return err
 			}
 			srckind.Type = typeForSrc
+		} else if w.objectProjection != projectAsNormal {
+			return fmt.Errorf("projections like OnlyMetadata are only supported for *source.Kind sources, got %T", w.src)
 		}
 
 		if err := blder.ctrl.Watch(w.src, w.eventhandler, allPredicates...); err != nil {
@@ -285,13 +328,19 @@ func (blder *Builder) doController(r reconcile.Reconciler) error {
 
 	// Retrieve the GVK from the object we're reconciling
 	// to prepopulate logger information, and to optionally generate a default name.
-	gvk, err := getGvk(blder.forInput.object, blder.mgr.GetScheme())
-	if err != nil {
-		return err
+	// Without a For type, Build has already ensured the controller is named.
+	var gvk schema.GroupVersionKind
+	hasGVK := blder.forInput.object != nil
+	if hasGVK {
+		var err error
+		gvk, err = getGvk(blder.forInput.object, blder.mgr.GetScheme())
+		if err != nil {
+			return err
+		}
 	}
 
 	// Setup concurrency.
-	if ctrlOptions.MaxConcurrentReconciles == 0 {
+	if ctrlOptions.MaxConcurrentReconciles == 0 && hasGVK {
 		groupKind := gvk.GroupKind().String()
 
 		if concurrency, ok := globalOpts.GroupKindConcurrency[groupKind]; ok && concurrency > 0 {
@@ -305,12 +354,18 @@ func (blder *Builder) doController(r reconcile.Reconciler) error {
 	}
 
 	// Setup the logger.
+	if blder.log != nil {
+		ctrlOptions.Log = blder.log
+	}
 	if ctrlOptions.Log == nil {
 		ctrlOptions.Log = blder.mgr.GetLogger()
 	}
-	ctrlOptions.Log = ctrlOptions.Log.WithValues("reconciler group", gvk.Group, "reconciler kind", gvk.Kind)
+	if hasGVK {
+		ctrlOptions.Log = ctrlOptions.Log.WithValues("controllerGroup", gvk.Group, "controllerKind", gvk.Kind)
+	}
 
 	// Build the controller and return.
+	var err error
 	blder.ctrl, err = newController(blder.getControllerName(gvk), blder.mgr, ctrlOptions)
 	return err
 }
@@ -81,6 +81,7 @@ var _ = Describe("application", func() {
 
 			instance, err := ControllerManagedBy(m).
 				For(&appsv1.ReplicaSet{}).
+				Named("replicaset-0").
 				Owns(&appsv1.ReplicaSet{}).
 				Build(noop)
 			Expect(err).NotTo(HaveOccurred())
@@ -113,6 +114,31 @@ var _ = Describe("application", func() {
 			Expect(instance).To(BeNil())
 		})
 
+		It("should require a name when only WatchesRawSource is used", func() {
+			By("creating a controller manager")
+			m, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			instance, err := ControllerManagedBy(m).
+				WatchesRawSource(&source.Channel{Source: make(chan event.GenericEvent)}, &handler.EnqueueRequestForObject{}).
+				Build(noop)
+			Expect(err).To(MatchError(ContainSubstring("must provide a name with Named()")))
+			Expect(instance).To(BeNil())
+		})
+
+		It("should build a controller from raw sources without For", func() {
+			By("creating a controller manager")
+			m, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			instance, err := ControllerManagedBy(m).
+				Named("raw-source-only").
+				WatchesRawSource(&source.Channel{Source: make(chan event.GenericEvent)}, &handler.EnqueueRequestForObject{}).
+				Build(noop)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).NotTo(BeNil())
+		})
+
 		It("should return an error if there is no GVK for an object, and thus we can't default the controller name", func() {
 			By("creating a controller manager")
 			m, err := manager.New(cfg, manager.Options{})
@@ -166,6 +192,7 @@ var _ = Describe("application", func() {
 
 			instance, err := ControllerManagedBy(m).
 				For(&appsv1.ReplicaSet{}).
+				Named("replicaset-1").
 				Owns(&appsv1.ReplicaSet{}).
 				WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}).
 				Build(noop)
@@ -195,6 +222,7 @@ var _ = Describe("application", func() {
 
 			instance, err := ControllerManagedBy(m).
 				For(&appsv1.ReplicaSet{}).
+				Named("replicaset-2").
 				Owns(&appsv1.ReplicaSet{}).
 				Build(noop)
 			Expect(err).NotTo(HaveOccurred())
@@ -216,6 +244,7 @@ var _ = Describe("application", func() {
 
 			instance, err := ControllerManagedBy(m).
 				For(&appsv1.ReplicaSet{}).
+				Named("replicaset-3").
 				Owns(&appsv1.ReplicaSet{}).
 				WithOptions(controller.Options{RateLimiter: rateLimiter}).
 				Build(noop)
@@ -239,6 +268,7 @@ var _ = Describe("application", func() {
 
 			instance, err := ControllerManagedBy(m).
 				For(&appsv1.ReplicaSet{}).
+				Named("replicaset-4").
 				Owns(&appsv1.ReplicaSet{}).
 				WithLogger(logger).
 				Build(noop)
@@ -246,6 +276,32 @@ var _ = Describe("application", func() {
 			Expect(instance).NotTo(BeNil())
 		})
 
+		It("should keep the logger set with WithLogger when setting options afterwards", func() {
+			logger := &testLogger{}
+			newController = func(name string, mgr manager.Manager, options controller.Options) (controller.Controller, error) {
+				if options.Log != logger {
+					return nil, fmt.Errorf("logger expected %T but found %T", logger, options.Log)
+				}
+				if options.MaxConcurrentReconciles != 2 {
+					return nil, fmt.Errorf("concurrency expected %d but found %d", 2, options.MaxConcurrentReconciles)
+				}
+				return controller.New(name, mgr, options)
+			}
+
+			By("creating a controller manager")
+			m, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			instance, err := ControllerManagedBy(m).
+				For(&appsv1.ReplicaSet{}).
+				Named("replicaset-5").
+				WithLogger(logger).
+				WithOptions(controller.Options{MaxConcurrentReconciles: 2}).
+				Build(noop)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).NotTo(BeNil())
+		})
+
 		It("should prefer reconciler from options during creation of controller", func() {
 			newController = func(name string, mgr manager.Manager, options controller.Options) (controller.Controller, error) {
 				if options.Reconciler != (typedNoop{}) {
@@ -260,6 +316,7 @@ var _ = Describe("application", func() {
 
 			instance, err := ControllerManagedBy(m).
 				For(&appsv1.ReplicaSet{}).
+				Named("replicaset-6").
 				Owns(&appsv1.ReplicaSet{}).
 				WithOptions(controller.Options{Reconciler: typedNoop{}}).
 				Build(noop)
@@ -281,6 +338,7 @@ var _ = Describe("application", func() {
 			By("creating the 1st controller")
 			ctrl1, err := ControllerManagedBy(m).
 				For(&TestDefaultValidator{}).
+				Named("testdefaultvalidator-0").
 				Owns(&appsv1.ReplicaSet{}).
 				Build(noop)
 			Expect(err).NotTo(HaveOccurred())
@@ -289,11 +347,32 @@ var _ = Describe("application", func() {
 			By("creating the 2nd controller")
 			ctrl2, err := ControllerManagedBy(m).
 				For(&TestDefaultValidator{}).
+				Named("testdefaultvalidator-1").
 				Owns(&appsv1.ReplicaSet{}).
 				Build(noop)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(ctrl2).NotTo(BeNil())
 		})
+
+		It("should name the controller as given by Named", func() {
+			newController = func(name string, mgr manager.Manager, options controller.Options) (controller.Controller, error) {
+				if name != "replicaset-scaler" {
+					return nil, fmt.Errorf("name expected %q but found %q", "replicaset-scaler", name)
+				}
+				return controller.New(name, mgr, options)
+			}
+
+			By("creating a controller manager")
+			m, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			instance, err := ControllerManagedBy(m).
+				For(&appsv1.ReplicaSet{}).
+				Named("replicaset-scaler").
+				Build(noop)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).NotTo(BeNil())
+		})
 	})
 
 	Describe("Start with ControllerManagedBy", func() {
@@ -326,6 +405,61 @@ var _ = Describe("application", func() {
 		}, 10)
 	})
 
+	Describe("Owns with MatchEveryOwner", func() {
+		It("should enqueue owners which are not the controller", func() {
+			input := OwnsInput{}
+			MatchEveryOwner.ApplyToOwns(&input)
+			Expect(input.matchEveryOwner).To(BeTrue())
+
+			m, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			ch := make(chan reconcile.Request, 1)
+			err = ControllerManagedBy(m).
+				For(&appsv1.Deployment{}).
+				Named("deployment-0").
+				Owns(&corev1.ConfigMap{}, MatchEveryOwner).
+				Complete(reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+					if req.Name == "deploy-name-every-owner" {
+						select {
+						case ch <- req:
+						default:
+						}
+					}
+					return reconcile.Result{}, nil
+				}))
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(m.Start(ctx)).NotTo(HaveOccurred())
+			}()
+
+			By("Creating a ConfigMap with a non-controller owner reference")
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      "cm-every-owner",
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							Name:       "deploy-name-every-owner",
+							Kind:       "Deployment",
+							APIVersion: "apps/v1",
+							UID:        "some-uid",
+						},
+					},
+				},
+			}
+			Expect(m.GetClient().Create(context.TODO(), cm)).To(Succeed())
+
+			By("Waiting for the owner to be reconciled")
+			Eventually(ch).Should(Receive(Equal(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "deploy-name-every-owner"}})))
+		}, 10)
+	})
+
 	Describe("Set custom predicates", func() {
 		It("should execute registered predicates only for assigned kind", func() {
 			m, err := manager.New(cfg, manager.Options{})
@@ -403,7 +537,7 @@ var _ = Describe("application", func() {
 				For(&appsv1.Deployment{}, OnlyMetadata).
 				Owns(&appsv1.ReplicaSet{}, OnlyMetadata).
 				Watches(&source.Kind{Type: &appsv1.StatefulSet{}},
-					handler.EnqueueRequestsFromMapFunc(func(o client.Object) []reconcile.Request {
+					handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []reconcile.Request {
 						defer GinkgoRecover()
 
 						ometa := o.(*metav1.PartialObjectMetadata)
@@ -461,6 +595,28 @@ var _ = Describe("application", func() {
 				return true
 			}).Should(BeTrue())
 		})
+
+		It("should return an error when projecting a source other than source.Kind", func() {
+			_, err := ControllerManagedBy(mgr).
+				For(&appsv1.Deployment{}).
+				Named("deployment-1").
+				Watches(&source.Channel{Source: make(chan event.GenericEvent)},
+					&handler.EnqueueRequestForObject{},
+					OnlyMetadata).
+				Build(noop)
+			Expect(err).To(MatchError(ContainSubstring("only supported for *source.Kind")))
+		})
+
+		It("should not project sources passed to WatchesRawSource", func() {
+			_, err := ControllerManagedBy(mgr).
+				For(&appsv1.Deployment{}).
+				Named("deployment-2").
+				WatchesRawSource(&source.Kind{Type: &appsv1.ReplicaSet{}},
+					&handler.EnqueueRequestForObject{},
+					OnlyMetadata).
+				Build(noop)
+			Expect(err).To(MatchError(ContainSubstring("not supported for sources passed to WatchesRawSource")))
+		})
 	})
 })
 
@@ -501,6 +657,8 @@ func doReconcileTest(ctx context.Context, nameSuffix string, blder *Builder, mgr
 	rsName := "rs-name-" + nameSuffix
 
 	By("Creating the application")
+	// Controller names must be unique within the process.
+	blder.Named("deployment-" + nameSuffix)
 	ch := make(chan reconcile.Request)
 	fn := reconcile.Func(func(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
 		defer GinkgoRecover()
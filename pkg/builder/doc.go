@@ -17,6 +17,17 @@ limitations under the License.
 // Package builder wraps other controller-runtime libraries and exposes simple
 // patterns for building common Controllers.
 //
+// A controller reconciling Deployments, which is also triggered by changes to the
+// Pods controlled by them, is built and added to a Manager with
+//
+//  err := builder.ControllerManagedBy(mgr).
+//      For(&appsv1.Deployment{}).
+//      Owns(&corev1.Pod{}).
+//      Complete(reconciler)
+//
+// The builder creates the controller, sets up the watches with the matching event
+// handlers, and injects the dependencies of the Manager into the reconciler.
+//
 // Projects built with the builder package can trivially be rebased on top of the underlying
 // packages if the project requires more customized behavior in the future.
 package builder
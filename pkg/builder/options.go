@@ -77,6 +77,29 @@ var _ WatchesOption = &Predicates{}
 
 // }}}
 
+// {{{ Owns Options
+
+// MatchEveryOwner determines whether the watch should be filtered based on
+// controller ownership, i.e. whether the OwnerReference.Controller field is set.
+//
+// If passed as an option, the handler receives notifications for every owner
+// of the object with the For type.  If unset (default), the handler receives
+// notifications only for the OwnerReference with `Controller: true`.  This is
+// needed when adopting pre-existing objects which have no controller
+// reference.
+var MatchEveryOwner = &matchEveryOwner{}
+
+type matchEveryOwner struct{}
+
+// ApplyToOwns applies this configuration to the given OwnsInput options.
+func (o matchEveryOwner) ApplyToOwns(opts *OwnsInput) {
+	opts.matchEveryOwner = true
+}
+
+var _ OwnsOption = MatchEveryOwner
+
+// }}}
+
 // {{{ For & Owns Dual-Type options
 
 // asProjection configures the projection (currently only metadata) on the input.
@@ -101,9 +124,10 @@ func (p projectAs) ApplyToWatches(opts *WatchesInput) {
 
 var (
 	// OnlyMetadata tells the controller to *only* cache metadata, and to watch
-	// the the API server in metadata-only form.  This is useful when watching
+	// the API server in metadata-only form.  This is useful when watching
 	// lots of objects, really big objects, or objects for which you only know
-	// the the GVK, but not the structure.  You'll need to pass
+	// the GVK, but not the structure.  When passed to Watches, the source must
+	// be a *source.Kind.  You'll need to pass
 	// metav1.PartialObjectMetadata to the client when fetching objects in your
 	// reconciler, otherwise you'll end up with a duplicate structured or
 	// unstructured cache.
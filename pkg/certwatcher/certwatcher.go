@@ -17,24 +17,35 @@ limitations under the License.
 package certwatcher
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"sync"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"k8s.io/apimachinery/pkg/util/wait"
 	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
 )
 
 var log = logf.RuntimeLog.WithName("certwatcher")
 
+// defaultWatchInterval is the default interval at which the certificate and key
+// files are re-read, in case a change wasn't observed through the file watch.
+const defaultWatchInterval = 10 * time.Second
+
 // CertWatcher watches certificate and key files for changes.  When either file
-// changes, it reads and parses both and calls an optional callback with the new
-// certificate.
+// changes, it reads and parses both and swaps in the new certificate.
+//
+// Besides watching the files, the certificate and key are also re-read periodically,
+// since file watches can miss updates, e.g. when a mounted Secret is updated through
+// a symlink swap on some filesystems.
 type CertWatcher struct {
 	sync.RWMutex
 
 	currentCert *tls.Certificate
 	watcher     *fsnotify.Watcher
+	interval    time.Duration
 
 	certPath string
 	keyPath  string
@@ -47,6 +58,7 @@ func New(certPath, keyPath string) (*CertWatcher, error) {
 	cw := &CertWatcher{
 		certPath: certPath,
 		keyPath:  keyPath,
+		interval: defaultWatchInterval,
 	}
 
 	// Initial read of certificate and key.
@@ -62,6 +74,17 @@ func New(certPath, keyPath string) (*CertWatcher, error) {
 	return cw, nil
 }
 
+// WithWatchInterval sets the interval at which the certificate and key are
+// re-read in addition to watching the files. Defaults to 10 seconds, which is
+// also used if the interval isn't positive.
+func (cw *CertWatcher) WithWatchInterval(interval time.Duration) *CertWatcher {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	cw.interval = interval
+	return cw
+}
+
 // GetCertificate fetches the currently loaded certificate, which may be nil.
 func (cw *CertWatcher) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
 	cw.RLock()
@@ -81,6 +104,12 @@ func (cw *CertWatcher) Start(ctx context.Context) error {
 
 	go cw.Watch()
 
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := cw.ReadCertificate(); err != nil {
+			log.Error(err, "error re-reading certificate")
+		}
+	}, cw.interval)
+
 	log.Info("Starting certificate watcher")
 
 	// Block until the context is done.
@@ -113,8 +142,7 @@ func (cw *CertWatcher) Watch() {
 }
 
 // ReadCertificate reads the certificate and key files from disk, parses them,
-// and updates the current certificate on the watcher.  If a callback is set, it
-// is invoked with the new certificate.
+// and updates the current certificate on the watcher if it changed.
 func (cw *CertWatcher) ReadCertificate() error {
 	cert, err := tls.LoadX509KeyPair(cw.certPath, cw.keyPath)
 	if err != nil {
@@ -122,14 +150,31 @@ func (cw *CertWatcher) ReadCertificate() error {
 	}
 
 	cw.Lock()
+	defer cw.Unlock()
+	if cw.currentCert != nil && sameCertificate(cw.currentCert, &cert) {
+		return nil
+	}
 	cw.currentCert = &cert
-	cw.Unlock()
 
 	log.Info("Updated current TLS certificate")
 
 	return nil
 }
 
+// sameCertificate returns true if both certificates have the same chain.
+// The private key always matches the leaf, so it doesn't need to be compared.
+func sameCertificate(a, b *tls.Certificate) bool {
+	if len(a.Certificate) != len(b.Certificate) {
+		return false
+	}
+	for i := range a.Certificate {
+		if !bytes.Equal(a.Certificate[i], b.Certificate[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func (cw *CertWatcher) handleEvent(event fsnotify.Event) {
 	// Only care about events which may modify the contents of the file.
 	if !(isWrite(event) || isRemove(event) || isCreate(event)) {
@@ -92,6 +92,32 @@ var _ = Describe("CertWatcher", func() {
 			Eventually(doneCh, "4s").Should(BeClosed())
 		})
 
+		It("should keep currentCert when re-reading an unchanged cert/key", func() {
+			firstcert, _ := watcher.GetCertificate(nil)
+			Expect(watcher.ReadCertificate()).To(Succeed())
+			secondcert, _ := watcher.GetCertificate(nil)
+			Expect(secondcert).To(BeIdenticalTo(firstcert))
+		})
+
+		It("should reload currentCert when changed with a short watch interval", func() {
+			watcher.WithWatchInterval(100 * time.Millisecond)
+			doneCh := startWatcher()
+
+			firstcert, _ := watcher.GetCertificate(nil)
+
+			err := writeCerts(certPath, keyPath, "192.168.0.2")
+			Expect(err).To(BeNil())
+
+			Eventually(func() bool {
+				secondcert, _ := watcher.GetCertificate(nil)
+				first := firstcert.PrivateKey.(*rsa.PrivateKey)
+				return first.Equal(secondcert.PrivateKey)
+			}).ShouldNot(BeTrue())
+
+			ctxCancel()
+			Eventually(doneCh, "4s").Should(BeClosed())
+		})
+
 		It("should reload currentCert when changed", func() {
 			doneCh := startWatcher()
 
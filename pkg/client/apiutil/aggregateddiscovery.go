@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// aggregatedDiscoveryAccept asks the server for all API groups, versions and resources of
+// an API path in a single APIGroupDiscoveryList.
+const aggregatedDiscoveryAccept = "application/json;g=apidiscovery.k8s.io;v=v2beta1;as=APIGroupDiscoveryList"
+
+// errAggregatedDiscoveryUnsupported is returned if the server doesn't serve aggregated discovery.
+var errAggregatedDiscoveryUnsupported = errors.New("aggregated discovery is not supported by the server")
+
+// The following types mirror the apidiscovery.k8s.io/v2beta1 API, which isn't part of the
+// client-go version this package is built against.  They only hold the fields needed to
+// build a RESTMapper.
+
+// apiGroupDiscoveryList is the aggregated discovery document of an API path.
+type apiGroupDiscoveryList struct {
+	Items []apiGroupDiscovery `json:"items"`
+}
+
+// apiGroupDiscovery holds the versions of an API group, in order of preference.
+type apiGroupDiscovery struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Versions          []apiVersionDiscovery `json:"versions"`
+}
+
+// apiVersionDiscovery holds the resources of an API group version.
+type apiVersionDiscovery struct {
+	Version   string                 `json:"version"`
+	Resources []apiResourceDiscovery `json:"resources"`
+}
+
+// apiResourceDiscovery describes a resource and its subresources.
+type apiResourceDiscovery struct {
+	Resource         string                    `json:"resource"`
+	ResponseKind     *metav1.GroupVersionKind  `json:"responseKind"`
+	Scope            string                    `json:"scope"`
+	SingularResource string                    `json:"singularResource"`
+	Verbs            []string                  `json:"verbs"`
+	ShortNames       []string                  `json:"shortNames"`
+	Categories       []string                  `json:"categories"`
+	Subresources     []apiSubresourceDiscovery `json:"subresources"`
+}
+
+// apiSubresourceDiscovery describes a subresource.
+type apiSubresourceDiscovery struct {
+	Subresource  string                   `json:"subresource"`
+	ResponseKind *metav1.GroupVersionKind `json:"responseKind"`
+	Verbs        []string                 `json:"verbs"`
+}
+
+// getAPIGroupResources returns the resources of all API groups of the server.  It uses
+// aggregated discovery, which needs a single request for each of /api and /apis, and falls
+// back to querying every API group one by one if the server doesn't support it.
+func getAPIGroupResources(client *discovery.DiscoveryClient) ([]*restmapper.APIGroupResources, error) {
+	restClient, ok := client.RESTClient().(*rest.RESTClient)
+	if !ok {
+		return restmapper.GetAPIGroupResources(client)
+	}
+
+	var groupResources []*restmapper.APIGroupResources
+	for _, path := range []string{"/api", "/apis"} {
+		list, err := getAggregatedDiscovery(context.TODO(), restClient, path)
+		if errors.Is(err, errAggregatedDiscoveryUnsupported) {
+			return restmapper.GetAPIGroupResources(client)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range list.Items {
+			groupResources = append(groupResources, group.apiGroupResources())
+		}
+	}
+	return groupResources, nil
+}
+
+// getAggregatedDiscovery gets the aggregated discovery document of the given API path.  It
+// returns errAggregatedDiscoveryUnsupported if the server responds with 406 Not Acceptable
+// or with a content type other than the one asked for, which is what servers without
+// aggregated discovery do.
+func getAggregatedDiscovery(ctx context.Context, restClient *rest.RESTClient, path string) (*apiGroupDiscoveryList, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, restClient.Get().AbsPath(path).URL().String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", aggregatedDiscoveryAccept)
+
+	httpClient := restClient.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotAcceptable {
+		return nil, errAggregatedDiscoveryUnsupported
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get aggregated discovery for %s: %s", path, resp.Status)
+	}
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" ||
+		params["g"] != "apidiscovery.k8s.io" || params["v"] != "v2beta1" || params["as"] != "APIGroupDiscoveryList" {
+		return nil, errAggregatedDiscoveryUnsupported
+	}
+
+	list := &apiGroupDiscoveryList{}
+	if err := json.NewDecoder(resp.Body).Decode(list); err != nil {
+		return nil, fmt.Errorf("failed to decode aggregated discovery for %s: %w", path, err)
+	}
+	return list, nil
+}
+
+// apiGroupResources converts the group to the form returned by legacy discovery.  The first
+// version is the preferred one.
+func (g apiGroupDiscovery) apiGroupResources() *restmapper.APIGroupResources {
+	groupResources := &restmapper.APIGroupResources{
+		Group:              metav1.APIGroup{Name: g.Name},
+		VersionedResources: map[string][]metav1.APIResource{},
+	}
+	for _, version := range g.Versions {
+		groupResources.Group.Versions = append(groupResources.Group.Versions, metav1.GroupVersionForDiscovery{
+			GroupVersion: schema.GroupVersion{Group: g.Name, Version: version.Version}.String(),
+			Version:      version.Version,
+		})
+
+		var resources []metav1.APIResource
+		for _, resource := range version.Resources {
+			resources = append(resources, resource.apiResources()...)
+		}
+		groupResources.VersionedResources[version.Version] = resources
+	}
+	if len(groupResources.Group.Versions) > 0 {
+		groupResources.Group.PreferredVersion = groupResources.Group.Versions[0]
+	}
+	return groupResources
+}
+
+// apiResources returns the resource followed by its subresources, named "resource/subresource"
+// like in legacy discovery.
+func (r apiResourceDiscovery) apiResources() []metav1.APIResource {
+	namespaced := r.Scope == "Namespaced"
+	resource := metav1.APIResource{
+		Name:         r.Resource,
+		SingularName: r.SingularResource,
+		Namespaced:   namespaced,
+		Verbs:        r.Verbs,
+		ShortNames:   r.ShortNames,
+		Categories:   r.Categories,
+	}
+	if r.ResponseKind != nil {
+		resource.Kind = r.ResponseKind.Kind
+	}
+
+	resources := []metav1.APIResource{resource}
+	for _, sub := range r.Subresources {
+		subresource := metav1.APIResource{
+			Name:       r.Resource + "/" + sub.Subresource,
+			Namespaced: namespaced,
+			Verbs:      sub.Verbs,
+		}
+		if sub.ResponseKind != nil {
+			subresource.Group = sub.ResponseKind.Group
+			subresource.Version = sub.ResponseKind.Version
+			subresource.Kind = sub.ResponseKind.Kind
+		}
+		resources = append(resources, subresource)
+	}
+	return resources
+}
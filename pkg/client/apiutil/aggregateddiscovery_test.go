@@ -0,0 +1,225 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiutil_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const aggregatedDiscoveryContentType = "application/json;g=apidiscovery.k8s.io;v=v2beta1;as=APIGroupDiscoveryList"
+
+// aggregatedDiscovery are the aggregated discovery documents of /api and /apis.
+var aggregatedDiscovery = map[string]interface{}{
+	"/api": map[string]interface{}{
+		"kind":       "APIGroupDiscoveryList",
+		"apiVersion": "apidiscovery.k8s.io/v2beta1",
+		"items": []interface{}{map[string]interface{}{
+			"metadata": map[string]interface{}{},
+			"versions": []interface{}{map[string]interface{}{
+				"version": "v1",
+				"resources": []interface{}{map[string]interface{}{
+					"resource":         "pods",
+					"responseKind":     map[string]interface{}{"group": "", "version": "v1", "kind": "Pod"},
+					"scope":            "Namespaced",
+					"singularResource": "pod",
+					"verbs":            []string{"get", "list"},
+					"subresources": []interface{}{map[string]interface{}{
+						"subresource":  "status",
+						"responseKind": map[string]interface{}{"group": "", "version": "v1", "kind": "Pod"},
+						"verbs":        []string{"get"},
+					}},
+				}},
+			}},
+		}},
+	},
+	"/apis": map[string]interface{}{
+		"kind":       "APIGroupDiscoveryList",
+		"apiVersion": "apidiscovery.k8s.io/v2beta1",
+		"items": []interface{}{map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "test.kubebuilder.io"},
+			"versions": []interface{}{
+				map[string]interface{}{
+					"version": "v1beta1",
+					"resources": []interface{}{map[string]interface{}{
+						"resource":         "somecrs",
+						"responseKind":     map[string]interface{}{"group": "test.kubebuilder.io", "version": "v1beta1", "kind": "SomeCR"},
+						"scope":            "Namespaced",
+						"singularResource": "somecr",
+						"verbs":            []string{"get", "list"},
+					}},
+				},
+				map[string]interface{}{
+					"version": "v1alpha1",
+					"resources": []interface{}{map[string]interface{}{
+						"resource":         "somecrs",
+						"responseKind":     map[string]interface{}{"group": "test.kubebuilder.io", "version": "v1alpha1", "kind": "SomeCR"},
+						"scope":            "Cluster",
+						"singularResource": "somecr",
+						"verbs":            []string{"get", "list"},
+					}},
+				},
+			},
+		}},
+	},
+}
+
+// legacyDiscovery are the discovery documents of servers without aggregated discovery.
+var legacyDiscovery = map[string]interface{}{
+	"/api": map[string]interface{}{
+		"kind":     "APIVersions",
+		"versions": []string{"v1"},
+	},
+	"/apis": map[string]interface{}{
+		"kind":       "APIGroupList",
+		"apiVersion": "v1",
+		"groups": []interface{}{map[string]interface{}{
+			"name":             "test.kubebuilder.io",
+			"versions":         []interface{}{map[string]interface{}{"groupVersion": "test.kubebuilder.io/v1beta1", "version": "v1beta1"}},
+			"preferredVersion": map[string]interface{}{"groupVersion": "test.kubebuilder.io/v1beta1", "version": "v1beta1"},
+		}},
+	},
+	"/api/v1": map[string]interface{}{
+		"kind":         "APIResourceList",
+		"apiVersion":   "v1",
+		"groupVersion": "v1",
+		"resources": []interface{}{map[string]interface{}{
+			"name": "pods", "singularName": "pod", "namespaced": true, "kind": "Pod", "verbs": []string{"get", "list"},
+		}},
+	},
+	"/apis/test.kubebuilder.io/v1beta1": map[string]interface{}{
+		"kind":         "APIResourceList",
+		"apiVersion":   "v1",
+		"groupVersion": "test.kubebuilder.io/v1beta1",
+		"resources": []interface{}{map[string]interface{}{
+			"name": "somecrs", "singularName": "somecr", "namespaced": true, "kind": "SomeCR", "verbs": []string{"get", "list"},
+		}},
+	},
+}
+
+var _ = Describe("Aggregated discovery", func() {
+	var (
+		mu       sync.Mutex
+		requests []string
+		serve    func(w http.ResponseWriter, r *http.Request)
+		server   *httptest.Server
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requests = append(requests, r.URL.Path)
+			mu.Unlock()
+			serve(w, r)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	writeJSON := func(w http.ResponseWriter, contentType string, doc interface{}) {
+		w.Header().Set("Content-Type", contentType)
+		Expect(json.NewEncoder(w).Encode(doc)).To(Succeed())
+	}
+
+	serveLegacy := func(w http.ResponseWriter, r *http.Request) {
+		doc, ok := legacyDiscovery[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, "application/json", doc)
+	}
+
+	expectMappings := func(mapper meta.RESTMapper) {
+		mapping, err := mapper.RESTMapping(schema.GroupKind{Kind: "Pod"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mapping.Resource).To(Equal(schema.GroupVersionResource{Version: "v1", Resource: "pods"}))
+		Expect(mapping.Scope).To(Equal(meta.RESTScopeNamespace))
+
+		mapping, err = mapper.RESTMapping(targetGVK.GroupKind())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*mapping).To(Equal(targetMapping))
+	}
+
+	It("should discover all groups with one request for each of /api and /apis", func() {
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			if !strings.Contains(r.Header.Get("Accept"), "as=APIGroupDiscoveryList") {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			writeJSON(w, aggregatedDiscoveryContentType, aggregatedDiscovery[r.URL.Path])
+		}
+
+		mapper, err := apiutil.NewDynamicRESTMapper(&rest.Config{Host: server.URL})
+		Expect(err).NotTo(HaveOccurred())
+		expectMappings(mapper)
+
+		By("preferring the first version of a group")
+		mappings, err := mapper.RESTMappings(targetGVK.GroupKind())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mappings).To(HaveLen(2))
+		Expect(mappings[0].GroupVersionKind).To(Equal(targetGVK))
+		Expect(mappings[1].Scope).To(Equal(meta.RESTScopeRoot))
+
+		Expect(requests).To(Equal([]string{"/api", "/apis"}))
+	})
+
+	It("should fall back to legacy discovery if the server responds with 406 Not Acceptable", func() {
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.Header.Get("Accept"), "as=APIGroupDiscoveryList") {
+				w.WriteHeader(http.StatusNotAcceptable)
+				return
+			}
+			serveLegacy(w, r)
+		}
+
+		mapper, err := apiutil.NewDynamicRESTMapper(&rest.Config{Host: server.URL})
+		Expect(err).NotTo(HaveOccurred())
+		expectMappings(mapper)
+	})
+
+	It("should fall back to legacy discovery if the server responds with another content type", func() {
+		serve = serveLegacy
+
+		mapper, err := apiutil.NewDynamicRESTMapper(&rest.Config{Host: server.URL})
+		Expect(err).NotTo(HaveOccurred())
+		expectMappings(mapper)
+	})
+
+	It("should return an error if aggregated discovery fails", func() {
+		serve = func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}
+
+		_, err := apiutil.NewDynamicRESTMapper(&rest.Config{Host: server.URL})
+		Expect(err).To(MatchError(ContainSubstring("403 Forbidden")))
+	})
+})
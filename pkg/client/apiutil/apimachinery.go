@@ -64,7 +64,7 @@ func NewDiscoveryRESTMapper(c *rest.Config) (meta.RESTMapper, error) {
 	if err != nil {
 		return nil, err
 	}
-	gr, err := restmapper.GetAPIGroupResources(dc)
+	gr, err := getAPIGroupResources(dc)
 	if err != nil {
 		return nil, err
 	}
@@ -72,6 +72,8 @@ func NewDiscoveryRESTMapper(c *rest.Config) (meta.RESTMapper, error) {
 }
 
 // GVKForObject finds the GroupVersionKind associated with the given object, if there is only a single such GVK.
+// Unstructured and metadata-only objects must have their GVK set, typed objects must be registered with scheme.
+// The returned error tells which of these requirements isn't met.
 func GVKForObject(obj runtime.Object, scheme *runtime.Scheme) (schema.GroupVersionKind, error) {
 	// TODO(directxman12): do we want to generalize this to arbitrary container types?
 	// I think we'd need a generalized form of scheme or something.  It's a
@@ -86,10 +88,10 @@ func GVKForObject(obj runtime.Object, scheme *runtime.Scheme) (schema.GroupVersi
 		// we require that the GVK be populated in order to recognize the object
 		gvk := obj.GetObjectKind().GroupVersionKind()
 		if len(gvk.Kind) == 0 {
-			return schema.GroupVersionKind{}, runtime.NewMissingKindErr("unstructured object has no kind")
+			return schema.GroupVersionKind{}, runtime.NewMissingKindErr(fmt.Sprintf("%T has no kind, it must be set for metadata-only objects", obj))
 		}
 		if len(gvk.Version) == 0 {
-			return schema.GroupVersionKind{}, runtime.NewMissingVersionErr("unstructured object has no version")
+			return schema.GroupVersionKind{}, runtime.NewMissingVersionErr(fmt.Sprintf("%T has no version, it must be set for metadata-only objects", obj))
 		}
 		return gvk, nil
 	}
@@ -109,7 +111,7 @@ func GVKForObject(obj runtime.Object, scheme *runtime.Scheme) (schema.GroupVersi
 		// this should only trigger for things like metav1.XYZ --
 		// normal versioned types should be fine
 		return schema.GroupVersionKind{}, fmt.Errorf(
-			"multiple group-version-kinds associated with type %T, refusing to guess at one", obj)
+			"multiple group-version-kinds associated with type %T, refusing to guess at one: %v", obj, gvks)
 	}
 	return gvks[0], nil
 }
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiutil_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+var _ = Describe("API Machinery", func() {
+	Describe("GVKForObject", func() {
+		It("should return the GVK of a registered type", func() {
+			gvk, err := apiutil.GVKForObject(&corev1.Pod{}, scheme.Scheme)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gvk).To(Equal(corev1.SchemeGroupVersion.WithKind("Pod")))
+		})
+
+		It("should return an error for a type that isn't registered", func() {
+			_, err := apiutil.GVKForObject(&corev1.Pod{}, runtime.NewScheme())
+			Expect(err).To(HaveOccurred())
+			Expect(runtime.IsNotRegisteredError(err)).To(BeTrue())
+		})
+
+		It("should return the GVK set on a metadata-only object", func() {
+			obj := &metav1.PartialObjectMetadata{}
+			obj.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+			gvk, err := apiutil.GVKForObject(obj, runtime.NewScheme())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gvk).To(Equal(corev1.SchemeGroupVersion.WithKind("Pod")))
+		})
+
+		It("should return an error for a metadata-only object without a kind", func() {
+			_, err := apiutil.GVKForObject(&metav1.PartialObjectMetadata{}, scheme.Scheme)
+			Expect(err).To(MatchError(ContainSubstring("*v1.PartialObjectMetadata has no kind")))
+			Expect(runtime.IsMissingKind(err)).To(BeTrue())
+		})
+
+		It("should list the GVKs if a type is registered more than once", func() {
+			s := runtime.NewScheme()
+			s.AddKnownTypes(schema.GroupVersion{Group: "a", Version: "v1"}, &corev1.Pod{})
+			s.AddKnownTypes(schema.GroupVersion{Group: "b", Version: "v1"}, &corev1.Pod{})
+			_, err := apiutil.GVKForObject(&corev1.Pod{}, s)
+			Expect(err).To(MatchError(ContainSubstring("refusing to guess at one: [a/v1, Kind=Pod b/v1, Kind=Pod]")))
+		})
+	})
+
+	Describe("RESTClientForGVK", func() {
+		It("should use the legacy API path for the core group", func() {
+			c, err := apiutil.RESTClientForGVK(corev1.SchemeGroupVersion.WithKind("Pod"), false, cfg, serializer.NewCodecFactory(scheme.Scheme))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Get().URL().Path).To(Equal("/api/v1"))
+		})
+
+		It("should use the group API path for named groups", func() {
+			gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+			c, err := apiutil.RESTClientForGVK(gvk, true, cfg, serializer.NewCodecFactory(scheme.Scheme))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Get().URL().Path).To(Equal("/apis/apps/v1"))
+		})
+	})
+})
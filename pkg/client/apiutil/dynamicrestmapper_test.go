@@ -17,6 +17,7 @@ limitations under the License.
 package apiutil_test
 
 import (
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -148,8 +149,59 @@ var _ = Describe("Dynamic REST Mapper", func() {
 		})
 	}
 
-	PIt("should lazily initialize if the lazy option is used", func() {
+	It("should lazily initialize if the lazy option is used", func() {
+		count := 0
+		var err error
+		mapper, err = apiutil.NewDynamicRESTMapper(cfg, apiutil.WithLazyDiscovery, apiutil.WithCustomMapper(func() (meta.RESTMapper, error) {
+			count++
+			baseMapper := meta.NewDefaultRESTMapper(nil)
+			addToMapper(baseMapper)
+			return baseMapper, nil
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(0))
 
+		gvk, err := mapper.KindFor(targetGVR)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gvk).To(Equal(targetGVK))
+		Expect(count).To(Equal(1))
+	})
+
+	It("should retry a failed lazy initialization", func() {
+		fail := true
+		var err error
+		mapper, err = apiutil.NewDynamicRESTMapper(cfg, apiutil.WithLazyDiscovery, apiutil.WithCustomMapper(func() (meta.RESTMapper, error) {
+			if fail {
+				return nil, fmt.Errorf("discovery failed")
+			}
+			baseMapper := meta.NewDefaultRESTMapper(nil)
+			addToMapper(baseMapper)
+			return baseMapper, nil
+		}))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = mapper.KindFor(targetGVR)
+		Expect(err).To(MatchError("discovery failed"))
+
+		fail = false
+		gvk, err := mapper.KindFor(targetGVR)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gvk).To(Equal(targetGVK))
+	})
+
+	It("should not reload on errors other than a missing kind or resource", func() {
+		count := 0
+		var err error
+		mapper, err = apiutil.NewDynamicRESTMapper(cfg, apiutil.WithCustomMapper(func() (meta.RESTMapper, error) {
+			count++
+			return ambiguousMapper{RESTMapper: meta.NewDefaultRESTMapper(nil)}, nil
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+
+		_, err = mapper.KindFor(targetGVR)
+		Expect(err).To(BeAssignableToTypeOf(&meta.AmbiguousResourceError{}))
+		Expect(count).To(Equal(1))
 	})
 
 	Describe("KindFor", func() {
@@ -265,6 +317,15 @@ var _ = Describe("Dynamic REST Mapper", func() {
 	})
 })
 
+// ambiguousMapper is a RESTMapper for which every resource is ambiguous.
+type ambiguousMapper struct {
+	meta.RESTMapper
+}
+
+func (m ambiguousMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	return schema.GroupVersionKind{}, &meta.AmbiguousResourceError{PartialResource: resource}
+}
+
 func beNoMatchError() types.GomegaMatcher {
 	return noMatchErrorMatcher{}
 }
@@ -32,6 +32,7 @@ import (
 	"k8s.io/client-go/rest"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -83,7 +84,7 @@ func newClient(config *rest.Config, options Options) (*client, error) {
 
 	if !options.Opts.SuppressWarnings {
 		// surface warnings
-		logger := log.Log.WithName("KubeAPIWarningLogger")
+		logger := logf.RuntimeLog.WithName("KubeAPIWarningLogger")
 		// Set a WarningHandler, the default WarningHandler
 		// is log.KubeAPIWarningLogger with deduplication enabled.
 		// See log.KubeAPIWarningLoggerOptions for considerations
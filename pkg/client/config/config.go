@@ -30,14 +30,32 @@ import (
 )
 
 var (
-	kubeconfig string
-	log        = logf.RuntimeLog.WithName("client").WithName("config")
+	kubeconfig  string
+	kubeContext string
+	log         = logf.RuntimeLog.WithName("client").WithName("config")
 )
 
 func init() {
 	// TODO: Fix this to allow double vendoring this library but still register flags on behalf of users
-	flag.StringVar(&kubeconfig, "kubeconfig", "",
-		"Paths to a kubeconfig. Only required if out-of-cluster.")
+	RegisterFlags(flag.CommandLine)
+}
+
+// RegisterFlags registers the --kubeconfig and --kube-context flags read by GetConfig on the
+// given FlagSet, skipping flags the FlagSet already has.  A nil FlagSet means flag.CommandLine,
+// on which the flags are already registered when this package is imported.  This lets tools
+// that parse their own FlagSet share the config loading of this package.
+func RegisterFlags(fs *flag.FlagSet) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	if fs.Lookup("kubeconfig") == nil {
+		fs.StringVar(&kubeconfig, "kubeconfig", "",
+			"Paths to a kubeconfig. Only required if out-of-cluster.")
+	}
+	if fs.Lookup("kube-context") == nil {
+		fs.StringVar(&kubeContext, "kube-context", "",
+			"The name of the kubeconfig context to use. Defaults to the current context of the kubeconfig.")
+	}
 }
 
 // GetConfig creates a *rest.Config for talking to a Kubernetes API server.
@@ -47,6 +65,9 @@ func init() {
 // It also applies saner defaults for QPS and burst based on the Kubernetes
 // controller manager defaults (20 QPS, 30 burst)
 //
+// The kubeconfig context defaults to the one set with --kube-context, or to
+// the current context of the kubeconfig.
+//
 // Config precedence
 //
 // * --kubeconfig flag pointing at a file
@@ -57,7 +78,7 @@ func init() {
 //
 // * $HOME/.kube/config if exists.
 func GetConfig() (*rest.Config, error) {
-	return GetConfigWithContext("")
+	return GetConfigWithContext(kubeContext)
 }
 
 // GetConfigWithContext creates a *rest.Config for talking to a Kubernetes API server with a specific context.
@@ -76,18 +97,46 @@ func GetConfig() (*rest.Config, error) {
 // * In-cluster config if running in cluster
 //
 // * $HOME/.kube/config if exists.
+//
+// Use GetConfigFrom to change this order.
 func GetConfigWithContext(context string) (*rest.Config, error) {
-	cfg, err := loadConfig(context)
-	if err != nil {
-		return nil, err
-	}
+	return GetConfigFrom(context, DefaultLoaders()...)
+}
 
-	if cfg.QPS == 0.0 {
-		cfg.QPS = 20.0
-		cfg.Burst = 30.0
-	}
+// Loader loads a *rest.Config from a single location, using the given kubeconfig
+// context where that applies.  It returns a nil config and no error if its location
+// isn't available, e.g. because a flag isn't set, so that the next Loader is tried.
+//
+// Exec credential plugins and proxy settings from a kubeconfig are part of the
+// returned config, and are kept by all clients built from (copies of) it.
+type Loader func(context string) (*rest.Config, error)
+
+// DefaultLoaders returns the Loaders in the order used by GetConfig:
+// KubeconfigFlagLoader, KubeconfigEnvLoader, InClusterLoader and HomeKubeconfigLoader.
+func DefaultLoaders() []Loader {
+	return []Loader{KubeconfigFlagLoader, KubeconfigEnvLoader, InClusterLoader, HomeKubeconfigLoader}
+}
 
-	return cfg, nil
+// GetConfigFrom creates a *rest.Config like GetConfigWithContext, but tries the given
+// Loaders in order instead of the default precedence.  The first config found is used,
+// and the first error returned by a Loader is returned.
+func GetConfigFrom(context string, loaders ...Loader) (*rest.Config, error) {
+	for _, load := range loaders {
+		cfg, err := load(context)
+		if err != nil {
+			return nil, err
+		}
+		if cfg == nil {
+			continue
+		}
+
+		if cfg.QPS == 0.0 {
+			cfg.QPS = 20.0
+			cfg.Burst = 30.0
+		}
+		return cfg, nil
+	}
+	return nil, fmt.Errorf("could not locate a kubeconfig")
 }
 
 // loadInClusterConfig is a function used to load the in-cluster
@@ -95,31 +144,44 @@ func GetConfigWithContext(context string) (*rest.Config, error) {
 // test the precedence of loading the config.
 var loadInClusterConfig = rest.InClusterConfig
 
-// loadConfig loads a REST Config as per the rules specified in GetConfig.
-func loadConfig(context string) (*rest.Config, error) {
-	// If a flag is specified with the config location, use that
-	if len(kubeconfig) > 0 {
-		return loadConfigWithContext("", &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig}, context)
+// KubeconfigFlagLoader loads the kubeconfig file set with the --kubeconfig flag.
+func KubeconfigFlagLoader(context string) (*rest.Config, error) {
+	if len(kubeconfig) == 0 {
+		return nil, nil
 	}
+	return loadConfigWithContext("", &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig}, context)
+}
 
-	// If the recommended kubeconfig env variable is not specified,
-	// try the in-cluster config.
+// KubeconfigEnvLoader loads the kubeconfig files listed in the KUBECONFIG environment variable.
+func KubeconfigEnvLoader(context string) (*rest.Config, error) {
 	kubeconfigPath := os.Getenv(clientcmd.RecommendedConfigPathEnvVar)
 	if len(kubeconfigPath) == 0 {
-		if c, err := loadInClusterConfig(); err == nil {
-			return c, nil
-		}
+		return nil, nil
 	}
+	return loadConfigWithContext("", clientcmd.NewDefaultClientConfigLoadingRules(), context)
+}
 
-	// If the recommended kubeconfig env variable is set, or there
-	// is no in-cluster config, try the default recommended locations.
-	//
+// InClusterLoader loads the config of the service account of the pod this process
+// runs in.  It ignores the context.
+func InClusterLoader(_ string) (*rest.Config, error) {
+	c, err := loadInClusterConfig()
+	if err != nil {
+		// Not running in a cluster, or not able to use its config.
+		return nil, nil //nolint:nilerr
+	}
+	return c, nil
+}
+
+// HomeKubeconfigLoader loads $HOME/.kube/config.  It returns a nil config
+// if that file doesn't exist.
+func HomeKubeconfigLoader(context string) (*rest.Config, error) {
 	// NOTE: For default config file locations, upstream only checks
 	// $HOME for the user's home directory, but we can also try
 	// os/user.HomeDir when $HOME is unset.
 	//
 	// TODO(jlanford): could this be done upstream?
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.Precedence = []string{clientcmd.RecommendedHomeFile}
 	if _, ok := os.LookupEnv("HOME"); !ok {
 		u, err := user.Current()
 		if err != nil {
@@ -128,6 +190,17 @@ func loadConfig(context string) (*rest.Config, error) {
 		loadingRules.Precedence = append(loadingRules.Precedence, path.Join(u.HomeDir, clientcmd.RecommendedHomeDir, clientcmd.RecommendedFileName))
 	}
 
+	var existing []string
+	for _, file := range loadingRules.Precedence {
+		if _, err := os.Stat(file); err == nil {
+			existing = append(existing, file)
+		}
+	}
+	if len(existing) == 0 {
+		return nil, nil
+	}
+	loadingRules.Precedence = existing
+
 	return loadConfigWithContext("", loadingRules, context)
 }
 
@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	"flag"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -55,6 +56,7 @@ var _ = Describe("Config", func() {
 	AfterEach(func() {
 		os.Unsetenv(clientcmd.RecommendedConfigPathEnvVar)
 		kubeconfig = ""
+		kubeContext = ""
 		clientcmd.RecommendedHomeFile = origRecommendedHomeFile
 
 		err := os.RemoveAll(dir)
@@ -172,6 +174,109 @@ var _ = Describe("Config", func() {
 			defineTests(testCases)
 		})
 	})
+
+	Describe("GetConfigFrom", func() {
+		BeforeEach(func() {
+			Expect(createFiles(map[string]string{
+				"kubeconfig-env-1": genKubeconfig("from-env-1"),
+				".kubeconfig":      genKubeconfig("from-home"),
+			}, dir)).To(Succeed())
+			setConfigs(testCase{kubeconfigEnv: []string{"kubeconfig-env-1"}}, dir)
+		})
+
+		It("should use the loaders in the given order", func() {
+			cfg, err := GetConfigFrom("", HomeKubeconfigLoader, KubeconfigEnvLoader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Host).To(Equal("from-home"))
+		})
+
+		It("should skip loaders whose location isn't available", func() {
+			cfg, err := GetConfigFrom("", KubeconfigFlagLoader, KubeconfigEnvLoader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Host).To(Equal("from-env-1"))
+			Expect(cfg.QPS).To(BeEquivalentTo(20))
+		})
+
+		It("should fall through to the next loader if the home file doesn't exist", func() {
+			Expect(os.Remove(filepath.Join(dir, ".kubeconfig"))).To(Succeed())
+			loadInClusterConfig = func() (*rest.Config, error) {
+				return &rest.Config{Host: "from-in-cluster"}, nil
+			}
+			defer func() { loadInClusterConfig = rest.InClusterConfig }()
+
+			cfg, err := GetConfigFrom("", HomeKubeconfigLoader, InClusterLoader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Host).To(Equal("from-in-cluster"))
+		})
+
+		It("should fail if no loader finds a config", func() {
+			_, err := GetConfigFrom("", KubeconfigFlagLoader)
+			Expect(err).To(MatchError("could not locate a kubeconfig"))
+		})
+
+		It("should keep exec credential plugins from the kubeconfig", func() {
+			Expect(createFiles(map[string]string{"kubeconfig-exec": `apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: https://from-exec
+contexts:
+- name: context
+  context:
+    cluster: cluster
+    user: sso
+current-context: context
+users:
+- name: sso
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: sso-login
+`}, dir)).To(Succeed())
+			kubeconfig = filepath.Join(dir, "kubeconfig-exec")
+
+			cfg, err := GetConfigFrom("", KubeconfigFlagLoader)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.ExecProvider).NotTo(BeNil())
+			Expect(cfg.ExecProvider.Command).To(Equal("sso-login"))
+			Expect(rest.CopyConfig(cfg).ExecProvider).To(Equal(cfg.ExecProvider))
+		})
+	})
+
+	Describe("RegisterFlags", func() {
+		It("should register the --kubeconfig flag on the given FlagSet", func() {
+			Expect(createFiles(map[string]string{"kubeconfig-flag": genKubeconfig("from-flag")}, dir)).To(Succeed())
+
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			RegisterFlags(fs)
+			Expect(fs.Parse([]string{"--kubeconfig", filepath.Join(dir, "kubeconfig-flag")})).To(Succeed())
+
+			cfg, err := GetConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Host).To(Equal("from-flag"))
+		})
+
+		It("should use the context from the --kube-context flag", func() {
+			Expect(createFiles(map[string]string{"kubeconfig-multi-context": genKubeconfig("from-multi-env-1", "from-multi-env-2")}, dir)).To(Succeed())
+			Expect(os.Setenv(clientcmd.RecommendedConfigPathEnvVar, filepath.Join(dir, "kubeconfig-multi-context"))).To(Succeed())
+
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			RegisterFlags(fs)
+			Expect(fs.Parse([]string{"--kube-context", "from-multi-env-2"})).To(Succeed())
+
+			cfg, err := GetConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Host).To(Equal("from-multi-env-2"))
+		})
+
+		It("should not register the flags twice", func() {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			RegisterFlags(fs)
+			Expect(func() { RegisterFlags(fs) }).NotTo(Panic())
+			Expect(func() { RegisterFlags(nil) }).NotTo(Panic())
+		})
+	})
 })
 
 func setConfigs(tc testCase, dir string) {
@@ -129,6 +129,11 @@ type Options struct {
 	// is shorter than the lifetime of your process.
 	EventBroadcaster record.EventBroadcaster
 
+	// EventSink is where Events emitted by the cluster are recorded to, e.g. to
+	// also forward them to another system or to drop them in tests.
+	// Defaults to the core v1 Events API of the cluster.
+	EventSink record.EventSink
+
 	// makeBroadcaster allows deferring the creation of the broadcaster to
 	// avoid leaking goroutines if we never call Start on this manager.  It also
 	// returns whether or not this is a "owned" broadcaster, and as such should be
@@ -136,7 +141,7 @@ type Options struct {
 	makeBroadcaster intrec.EventBroadcasterProducer
 
 	// Dependency injection for testing
-	newRecorderProvider func(config *rest.Config, scheme *runtime.Scheme, logger logr.Logger, makeBroadcaster intrec.EventBroadcasterProducer) (*intrec.Provider, error)
+	newRecorderProvider func(config *rest.Config, scheme *runtime.Scheme, logger logr.Logger, makeBroadcaster intrec.EventBroadcasterProducer, sink record.EventSink) (*intrec.Provider, error)
 }
 
 // Option can be used to manipulate Options.
@@ -186,7 +191,7 @@ func New(config *rest.Config, opts ...Option) (Cluster, error) {
 	// Create the recorder provider to inject event recorders for the components.
 	// TODO(directxman12): the log for the event provider should have a context (name, tags, etc) specific
 	// to the particular controller that it's being injected into, rather than a generic one like is here.
-	recorderProvider, err := options.newRecorderProvider(config, options.Scheme, options.Logger.WithName("events"), options.makeBroadcaster)
+	recorderProvider, err := options.newRecorderProvider(config, options.Scheme, options.Logger.WithName("events"), options.makeBroadcaster, options.EventSink)
 	if err != nil {
 		return nil, err
 	}
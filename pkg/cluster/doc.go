@@ -0,0 +1,26 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package cluster provides the cached client, cache, scheme and RESTMapper used to interact with a
+single Kubernetes cluster.
+
+A Manager embeds a Cluster for the cluster it runs controllers against, but a Cluster can also be
+constructed on its own with New.  This is useful for tools that need cached reads without running
+any controllers, and for adding secondary clusters to a Manager.  A standalone Cluster must be
+started with Start, and its cache must be synced before reads through GetClient are served from it.
+*/
+package cluster
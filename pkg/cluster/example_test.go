@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"context"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+)
+
+var (
+	// NB: don't call SetLogger in init(), or else you'll mess up logging in the main suite.
+	log = logf.Log.WithName("cluster-examples")
+)
+
+// This example creates a standalone Cluster and reads from its cache without a Manager.
+func ExampleNew() {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		log.Error(err, "unable to get kubeconfig")
+		os.Exit(1)
+	}
+
+	cl, err := cluster.New(cfg, func(o *cluster.Options) {
+		o.Namespace = "default"
+	})
+	if err != nil {
+		log.Error(err, "unable to set up cluster")
+		os.Exit(1)
+	}
+
+	ctx := signals.SetupSignalHandler()
+	go func() {
+		if err := cl.Start(ctx); err != nil {
+			log.Error(err, "unable to start cluster")
+			os.Exit(1)
+		}
+	}()
+	if !cl.GetCache().WaitForCacheSync(ctx) {
+		log.Error(nil, "unable to sync the cluster cache")
+		os.Exit(1)
+	}
+
+	pods := &corev1.PodList{}
+	if err := cl.GetClient().List(context.Background(), pods, client.InNamespace("default")); err != nil {
+		log.Error(err, "unable to list pods")
+		os.Exit(1)
+	}
+	log.Info("listed pods", "count", len(pods.Items))
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+)
+
+// Provider discovers clusters at runtime, e.g. from kubeconfig Secrets, and
+// reports them to an Aware component such as a Manager.
+type Provider interface {
+	// Run blocks until the context is cancelled.  It calls Engage on aware for
+	// every cluster that becomes available, and Disengage for every cluster
+	// that goes away.  Clusters passed to Engage must not have been started yet.
+	Run(ctx context.Context, aware Aware) error
+}
+
+// Aware is notified by a Provider about clusters that come and go.
+type Aware interface {
+	// Engage starts the given cluster and makes it available under the given name.
+	// The cache of the cluster might not have synced yet when Engage returns.
+	// It returns an error if the Aware component isn't running.
+	Engage(name string, cl Cluster) error
+
+	// Disengage stops the cluster with the given name, waits for it to stop and
+	// forgets about it.
+	Disengage(name string) error
+}
@@ -26,6 +26,11 @@ func (in *ControllerConfigurationSpec) DeepCopyInto(out *ControllerConfiguration
 		*out = new(timex.Duration)
 		**out = **in
 	}
+	if in.SkipNameValidation != nil {
+		in, out := &in.SkipNameValidation, &out.SkipNameValidation
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConfigurationSpec.
@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+)
+
+// newCoalescingQueue wraps the given queue so that items added through Add only
+// become ready after the given delay. As queues only hold an item once, all adds
+// for the same item within the delay result in a single reconciliation.
+func newCoalescingQueue(q workqueue.RateLimitingInterface, delay time.Duration) workqueue.RateLimitingInterface {
+	if pq, isPriorityQueue := q.(priorityqueue.PriorityQueue); isPriorityQueue {
+		return &coalescingPriorityQueue{PriorityQueue: pq, delay: delay}
+	}
+	return &coalescingQueue{RateLimitingInterface: q, delay: delay}
+}
+
+type coalescingQueue struct {
+	workqueue.RateLimitingInterface
+	delay time.Duration
+}
+
+// Add implements workqueue.Interface.
+func (q *coalescingQueue) Add(item interface{}) {
+	q.RateLimitingInterface.AddAfter(item, q.delay)
+}
+
+type coalescingPriorityQueue struct {
+	priorityqueue.PriorityQueue
+	delay time.Duration
+}
+
+// Add implements workqueue.Interface.
+func (q *coalescingPriorityQueue) Add(item interface{}) {
+	q.PriorityQueue.AddAfter(item, q.delay)
+}
+
+// AddWithOpts implements priorityqueue.PriorityQueue.
+func (q *coalescingPriorityQueue) AddWithOpts(o priorityqueue.AddOpts, items ...interface{}) {
+	if !o.RateLimited && o.After < q.delay {
+		o.After = q.delay
+	}
+	q.PriorityQueue.AddWithOpts(o, items...)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+)
+
+var _ = Describe("coalescing queue", func() {
+	It("should coalesce adds for the same item within the delay", func() {
+		q := newCoalescingQueue(workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()), 100*time.Millisecond)
+		defer q.ShutDown()
+
+		q.Add("item")
+		q.Add("item")
+		Expect(q.Len()).To(Equal(0))
+
+		Eventually(q.Len).Should(Equal(1))
+		Consistently(q.Len, 200*time.Millisecond).Should(Equal(1))
+	})
+
+	It("should preserve the priority queue interface", func() {
+		q := newCoalescingQueue(priorityqueue.New("test", workqueue.DefaultControllerRateLimiter()), 100*time.Millisecond)
+		defer q.ShutDown()
+
+		pq, isPriorityQueue := q.(priorityqueue.PriorityQueue)
+		Expect(isPriorityQueue).To(BeTrue())
+
+		pq.AddWithOpts(priorityqueue.AddOpts{Priority: priorityqueue.LowPriority}, "low")
+		pq.Add("normal")
+		Expect(q.Len()).To(Equal(0))
+
+		Eventually(q.Len).Should(Equal(2))
+		item, _ := q.Get()
+		Expect(item).To(Equal("normal"))
+	})
+})
@@ -22,13 +22,16 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/internal/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
@@ -45,8 +48,39 @@ type Options struct {
 	// The overall is a token bucket and the per-item is exponential.
 	RateLimiter ratelimiter.RateLimiter
 
+	// NewQueue constructs the queue for this controller once the controller is ready to start.
+	// With NewQueue a custom queue implementation can be used, e.g. a priority queue to prioritize with which
+	// priority/order objects are reconciled (e.g. to reconcile objects with changes first).
+	// NewQueue is called with the name of the controller and the RateLimiter of the controller.
+	// Defaults to workqueue.NewNamedRateLimitingQueue(rateLimiter, controllerName), or to
+	// priorityqueue.New(controllerName, rateLimiter) if UsePriorityQueue is set.
+	NewQueue func(controllerName string, rateLimiter ratelimiter.RateLimiter) workqueue.RateLimitingInterface
+
+	// EventCoalescingDelay is the minimum time between an event handler enqueueing a request
+	// and the request being reconciled. All events for the same object within the delay are
+	// coalesced into a single reconciliation, which avoids redundant reconciles during bursts
+	// of updates to a single object. Requeues requested by the Reconciler are not delayed.
+	// Defaults to 0, which means requests are reconciled as soon as a worker is available.
+	EventCoalescingDelay time.Duration
+
+	// ErrorBackoff, if set, is called when Reconcile returns an error and determines how long
+	// to wait before the request is retried. If it returns false, the request is requeued
+	// using the RateLimiter, which is also the behavior if ErrorBackoff is unset.
+	// ErrorBackoff is not consulted for errors created with reconcile.TerminalError, or with
+	// reconcile.RetryAfterError and a positive duration.
+	ErrorBackoff func(req reconcile.Request, err error) (time.Duration, bool)
+
+	// ErrorVerbosity, if set, is called with every error returned by the Reconciler and
+	// determines the verbosity it is logged with. Errors for which it returns 0 are logged
+	// as errors, errors for which it returns a higher value are logged as info messages at
+	// that verbosity, so that expected errors, e.g. conflicts caused by optimistic concurrency,
+	// don't flood the logs. See ExpectedErrorVerbosity.
+	// Defaults to logging all errors as errors.
+	ErrorVerbosity func(err error) int
+
 	// Log is the logger used for this controller and passed to each reconciliation
-	// request via the context field.
+	// request via the context field. It is also injected into the Reconciler and into
+	// the sources, event handlers and predicates of the controller implementing inject.Logger.
 	Log logr.Logger
 
 	// CacheSyncTimeout refers to the time limit set to wait for syncing caches.
@@ -55,6 +89,39 @@ type Options struct {
 
 	// RecoverPanic indicates whether the panic caused by reconcile should be recovered.
 	RecoverPanic bool
+
+	// ReconciliationTimeout is the maximum duration a single call to Reconcile may take.
+	// The context passed to the Reconciler is cancelled once the timeout expires, and
+	// reconciliations returning a context.DeadlineExceeded error are counted as timeouts.
+	// Defaults to 0, which means no timeout.
+	ReconciliationTimeout time.Duration
+
+	// SkipNameValidation allows skipping the name validation that ensures that every controller name is unique.
+	// Unique controller names are important to get unique metrics and logs for a controller.
+	// Defaults to the Controller.SkipNameValidation setting from the Manager if unset.
+	// Defaults to false if Controller.SkipNameValidation setting from the Manager is also unset.
+	SkipNameValidation *bool
+
+	// NeedLeaderElection indicates whether the controller needs to use leader election.
+	// Defaults to true, which means the controller will use leader election.
+	// Controllers that set this to false are started by the manager regardless of
+	// whether this replica is the leader.
+	NeedLeaderElection *bool
+
+	// EnableWarmup specifies whether the controller should start its sources and wait for
+	// them to sync before the manager is elected leader. This reduces the time it takes a
+	// new leader to start reconciling after a failover, at the cost of running the
+	// informers on every replica.
+	// Defaults to false.
+	EnableWarmup *bool
+
+	// UsePriorityQueue configures the controller to use a priority queue. The event
+	// handlers of the handler package enqueue events from the initial list of a source
+	// and from resyncs with a lower priority than events caused by actual changes, so
+	// that these are reconciled first.
+	// Ignored if NewQueue is set.
+	// Defaults to false.
+	UsePriorityQueue *bool
 }
 
 // Controller implements a Kubernetes API.  A Controller manages a work queue fed reconcile.Requests
@@ -81,8 +148,31 @@ type Controller interface {
 	GetLogger() logr.Logger
 }
 
+// ExpectedErrorVerbosity returns an Options.ErrorVerbosity func logging errors that
+// are expected during normal operation, i.e. conflicts and not found errors
+// returned by the API server, at the given verbosity.
+func ExpectedErrorVerbosity(level int) func(err error) int {
+	return func(err error) int {
+		if apierrors.IsConflict(err) || apierrors.IsNotFound(err) {
+			return level
+		}
+		return 0
+	}
+}
+
+// ReconcileIDFromContext gets the reconcileID from the current context.
+// Controllers generate a new reconcileID for every reconciliation and add it
+// to the context passed to the Reconciler, as well as to the logger returned
+// by log.FromContext.
+var ReconcileIDFromContext = controller.ReconcileIDFromContext
+
 // New returns a new Controller registered with the Manager.  The Manager will ensure that shared Caches have
 // been synced before the Controller is Started.
+//
+// The name must be unique within the process (see Options.SkipNameValidation). It is
+// used as the name of the workqueue, as the "controller" label of the
+// controller metrics and as the "controller" key of the controller logger, and thus
+// should be a prometheus compatible name (underscores and alphanumeric characters only).
 func New(name string, mgr manager.Manager, options Options) (Controller, error) {
 	c, err := NewUnmanaged(name, mgr, options)
 	if err != nil {
@@ -90,7 +180,13 @@ func New(name string, mgr manager.Manager, options Options) (Controller, error)
 	}
 
 	// Add the controller as a Manager components
-	return c, mgr.Add(c)
+	if err := mgr.Add(c); err != nil {
+		if validateName(mgr, options) {
+			releaseName(name)
+		}
+		return c, err
+	}
+	return c, nil
 }
 
 // NewUnmanaged returns a new controller without adding it to the manager. The
@@ -120,22 +216,52 @@ func NewUnmanaged(name string, mgr manager.Manager, options Options) (Controller
 		options.RateLimiter = workqueue.DefaultControllerRateLimiter()
 	}
 
+	if options.NewQueue == nil {
+		options.NewQueue = func(controllerName string, rateLimiter ratelimiter.RateLimiter) workqueue.RateLimitingInterface {
+			if options.UsePriorityQueue != nil && *options.UsePriorityQueue {
+				return priorityqueue.New(controllerName, rateLimiter)
+			}
+			return workqueue.NewNamedRateLimitingQueue(rateLimiter, controllerName)
+		}
+	}
+
+	log := options.Log.WithValues("controller", name)
+
 	// Inject dependencies into Reconciler
 	if err := mgr.SetFields(options.Reconciler); err != nil {
 		return nil, err
 	}
+	if _, err := inject.LoggerInto(log, options.Reconciler); err != nil {
+		return nil, err
+	}
+
+	// Reserve the name last, so that it stays available if creating the controller fails.
+	if validateName(mgr, options) {
+		if err := checkName(name); err != nil {
+			return nil, err
+		}
+	}
 
 	// Create controller with dependencies set
 	return &controller.Controller{
 		Do: options.Reconciler,
 		MakeQueue: func() workqueue.RateLimitingInterface {
-			return workqueue.NewNamedRateLimitingQueue(options.RateLimiter, name)
+			queue := options.NewQueue(name, options.RateLimiter)
+			if options.EventCoalescingDelay > 0 {
+				queue = newCoalescingQueue(queue, options.EventCoalescingDelay)
+			}
+			return queue
 		},
 		MaxConcurrentReconciles: options.MaxConcurrentReconciles,
 		CacheSyncTimeout:        options.CacheSyncTimeout,
 		SetFields:               mgr.SetFields,
 		Name:                    name,
-		Log:                     options.Log.WithName("controller").WithName(name),
+		Log:                     log,
 		RecoverPanic:            options.RecoverPanic,
+		ReconciliationTimeout:   options.ReconciliationTimeout,
+		LeaderElected:           options.NeedLeaderElection,
+		EnableWarmup:            options.EnableWarmup,
+		ErrorBackoff:            options.ErrorBackoff,
+		ErrorVerbosity:          options.ErrorVerbosity,
 	}, nil
 }
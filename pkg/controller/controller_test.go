@@ -19,19 +19,32 @@ package controller_test
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"go.uber.org/goleak"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/config/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/leaderelection"
+	fakeleaderelection "sigs.k8s.io/controller-runtime/pkg/leaderelection/fake"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/recorder"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -69,6 +82,19 @@ var _ = Describe("controller.Controller", func() {
 			Expect(err.Error()).To(ContainSubstring("expected error"))
 		})
 
+		It("should not reserve the name if creating the controller fails", func() {
+			m, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			c, err := controller.New("c6", m, controller.Options{Reconciler: &failRec{}})
+			Expect(err).To(HaveOccurred())
+			Expect(c).To(BeNil())
+
+			c, err = controller.New("c6", m, controller.Options{Reconciler: rec})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).ToNot(BeNil())
+		})
+
 		It("should not return an error if two controllers are registered with different names", func() {
 			m, err := manager.New(cfg, manager.Options{})
 			Expect(err).NotTo(HaveOccurred())
@@ -82,6 +108,50 @@ var _ = Describe("controller.Controller", func() {
 			Expect(c2).ToNot(BeNil())
 		})
 
+		It("should return an error if two controllers are registered with the same name", func() {
+			m, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			c1, err := controller.New("c3", m, controller.Options{Reconciler: rec})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c1).ToNot(BeNil())
+
+			c2, err := controller.New("c3", m, controller.Options{Reconciler: rec})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("controller with name c3 already exists"))
+			Expect(c2).To(BeNil())
+		})
+
+		It("should not return an error if two controllers are registered with the same name and SkipNameValidation is set", func() {
+			m, err := manager.New(cfg, manager.Options{
+				Controller: v1alpha1.ControllerConfigurationSpec{
+					SkipNameValidation: pointer.BoolPtr(true),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			c1, err := controller.New("c4", m, controller.Options{Reconciler: rec})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c1).ToNot(BeNil())
+
+			c2, err := controller.New("c4", m, controller.Options{Reconciler: rec})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c2).ToNot(BeNil())
+		})
+
+		It("should not return an error if two controllers are registered with the same name and SkipNameValidation is set on the controller", func() {
+			m, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			c1, err := controller.New("c5", m, controller.Options{Reconciler: rec})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c1).ToNot(BeNil())
+
+			c2, err := controller.New("c5", m, controller.Options{Reconciler: rec, SkipNameValidation: pointer.BoolPtr(true)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c2).ToNot(BeNil())
+		})
+
 		It("should not leak goroutines when stopped", func() {
 			currentGRs := goleak.IgnoreCurrent()
 
@@ -134,7 +204,7 @@ var _ = Describe("controller.Controller", func() {
 			m, err := manager.New(cfg, manager.Options{})
 			Expect(err).NotTo(HaveOccurred())
 
-			_, err = controller.New("new-controller", m, controller.Options{Reconciler: rec})
+			_, err = controller.New("new-controller-not-started", m, controller.Options{Reconciler: rec})
 			Expect(err).NotTo(HaveOccurred())
 
 			// force-close keep-alive connections.  These'll time anyway (after
@@ -142,6 +212,99 @@ var _ = Describe("controller.Controller", func() {
 			clientTransport.CloseIdleConnections()
 			Eventually(func() error { return goleak.Find(currentGRs) }).Should(Succeed())
 		})
+
+		It("should construct the queue using NewQueue when started", func() {
+			m, err := manager.New(cfg, manager.Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			rateLimiter := workqueue.DefaultItemBasedRateLimiter()
+			queueCreated := make(chan string, 1)
+			c, err := controller.New("new-controller-custom-queue", m, controller.Options{
+				Reconciler:  rec,
+				RateLimiter: rateLimiter,
+				NewQueue: func(controllerName string, rl ratelimiter.RateLimiter) workqueue.RateLimitingInterface {
+					defer GinkgoRecover()
+					Expect(rl).To(Equal(rateLimiter))
+					queueCreated <- controllerName
+					return workqueue.NewRateLimitingQueue(rl)
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c).NotTo(BeNil())
+			Expect(queueCreated).NotTo(Receive())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(m.Start(ctx)).To(Succeed())
+			}()
+			Eventually(queueCreated).Should(Receive(Equal("new-controller-custom-queue")))
+		})
+	})
+
+	Describe("Warmup", func() {
+		It("should retry starting the sources once elected if warmup failed", func() {
+			elected := make(chan struct{})
+			m, err := manager.New(cfg, manager.Options{
+				LeaderElection:          true,
+				LeaderElectionID:        "test-warmup-retry",
+				LeaderElectionNamespace: "default",
+				LeaderElectionResourceLockProvider: func(config *rest.Config, recorderProvider recorder.Provider, options leaderelection.Options) (resourcelock.Interface, error) {
+					rl, err := fakeleaderelection.NewResourceLock(config, recorderProvider, options)
+					if err != nil {
+						return nil, err
+					}
+					return &gatedResourceLock{Interface: rl, gate: elected}, nil
+				},
+				MetricsBindAddress: "0",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			c, err := controller.New("warmup-retry-controller", m, controller.Options{
+				Reconciler:   rec,
+				EnableWarmup: pointer.BoolPtr(true),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			var attempts int32
+			Expect(c.Watch(source.Func(func(context.Context, handler.EventHandler, workqueue.RateLimitingInterface, ...predicate.Predicate) error {
+				if atomic.AddInt32(&attempts, 1) == 1 {
+					return fmt.Errorf("CRD not installed yet")
+				}
+				return nil
+			}), &handler.EnqueueRequestForObject{})).To(Succeed())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- m.Start(ctx)
+			}()
+
+			By("failing to warm up before being elected")
+			Eventually(func() int32 { return atomic.LoadInt32(&attempts) }).Should(Equal(int32(1)))
+			Expect(m.Elected()).NotTo(BeClosed())
+
+			By("starting the sources again once elected")
+			close(elected)
+			Eventually(m.Elected()).Should(BeClosed())
+			Eventually(func() int32 { return atomic.LoadInt32(&attempts) }).Should(Equal(int32(2)))
+			Consistently(errCh).ShouldNot(Receive())
+		})
+	})
+
+	Describe("ExpectedErrorVerbosity", func() {
+		It("should lower the verbosity of conflicts and not found errors only", func() {
+			verbosity := controller.ExpectedErrorVerbosity(3)
+			gr := schema.GroupResource{Resource: "pods"}
+
+			Expect(verbosity(apierrors.NewConflict(gr, "foo", fmt.Errorf("changed")))).To(Equal(3))
+			Expect(verbosity(apierrors.NewNotFound(gr, "foo"))).To(Equal(3))
+			Expect(verbosity(fmt.Errorf("wrapped: %w", apierrors.NewNotFound(gr, "foo")))).To(Equal(3))
+			Expect(verbosity(apierrors.NewForbidden(gr, "foo", fmt.Errorf("denied")))).To(Equal(0))
+			Expect(verbosity(fmt.Errorf("expected error"))).To(Equal(0))
+		})
 	})
 })
 
@@ -157,3 +320,18 @@ func (*failRec) Reconcile(context.Context, reconcile.Request) (reconcile.Result,
 func (*failRec) InjectClient(client.Client) error {
 	return fmt.Errorf("expected error")
 }
+
+// gatedResourceLock is a resource lock that can't be acquired until gate is closed.
+type gatedResourceLock struct {
+	resourcelock.Interface
+	gate <-chan struct{}
+}
+
+func (l *gatedResourceLock) Get(ctx context.Context) (*resourcelock.LeaderElectionRecord, []byte, error) {
+	select {
+	case <-l.gate:
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+	return l.Interface.Get(ctx)
+}
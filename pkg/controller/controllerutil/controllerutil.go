@@ -120,6 +120,59 @@ func SetOwnerReference(owner, object metav1.Object, scheme *runtime.Scheme) erro
 	return nil
 }
 
+// RemoveOwnerReference is a helper method to make sure the given object removes an owner reference to the object provided.
+// This allows you to remove the owner to establish a new owner of the object in a subsequent call.
+// It returns an error if the object has no owner reference to owner.
+func RemoveOwnerReference(owner, object metav1.Object, scheme *runtime.Scheme) error {
+	ref, err := ownerRefFor(owner, scheme, "RemoveOwnerReference")
+	if err != nil {
+		return err
+	}
+
+	owners := object.GetOwnerReferences()
+	idx := indexOwnerRef(owners, ref)
+	if idx == -1 {
+		return fmt.Errorf("%T does not have an owner reference for %T %s", object, owner, owner.GetName())
+	}
+	owners = append(owners[:idx], owners[idx+1:]...)
+	object.SetOwnerReferences(owners)
+	return nil
+}
+
+// HasOwnerReference returns true if the object has an owner reference to owner,
+// regardless of whether it's a controller reference.
+func HasOwnerReference(owner, object metav1.Object, scheme *runtime.Scheme) (bool, error) {
+	ref, err := ownerRefFor(owner, scheme, "HasOwnerReference")
+	if err != nil {
+		return false, err
+	}
+	return indexOwnerRef(object.GetOwnerReferences(), ref) != -1, nil
+}
+
+// HasControllerReference returns true if the object has an owner reference
+// with the Controller flag set.
+func HasControllerReference(object metav1.Object) bool {
+	return metav1.GetControllerOf(object) != nil
+}
+
+// ownerRefFor returns an owner reference to owner, containing only the
+// fields needed to identify it.
+func ownerRefFor(owner metav1.Object, scheme *runtime.Scheme, caller string) (metav1.OwnerReference, error) {
+	ro, ok := owner.(runtime.Object)
+	if !ok {
+		return metav1.OwnerReference{}, fmt.Errorf("%T is not a runtime.Object, cannot call %s", owner, caller)
+	}
+	gvk, err := apiutil.GVKForObject(ro, scheme)
+	if err != nil {
+		return metav1.OwnerReference{}, err
+	}
+	return metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       owner.GetName(),
+	}, nil
+}
+
 func upsertOwnerRef(ref metav1.OwnerReference, object metav1.Object) {
 	owners := object.GetOwnerReferences()
 	if idx := indexOwnerRef(owners, ref); idx == -1 {
@@ -169,7 +222,12 @@ func referSameObject(a, b metav1.OwnerReference) bool {
 	return aGV.Group == bGV.Group && a.Kind == b.Kind && a.Name == b.Name
 }
 
-// OperationResult is the action result of a CreateOrUpdate call.
+// OperationResult is the action result of a CreateOrUpdate or CreateOrPatch
+// call.  It allows callers to emit events or metrics about what actually
+// changed without diffing the objects themselves.  CreateOrUpdate returns
+// OperationResultNone, OperationResultCreated or OperationResultUpdated,
+// CreateOrPatch can also return OperationResultUpdatedStatus and
+// OperationResultUpdatedStatusOnly, as it patches the status separately.
 type OperationResult string
 
 const ( // They should complete the sentence "Deployment default/foo has been ..."
@@ -189,7 +247,8 @@ const ( // They should complete the sentence "Deployment default/foo has been ..
 // cluster. The object's desired state must be reconciled with the existing
 // state inside the passed in callback MutateFn.
 //
-// The MutateFn is called regardless of creating or updating an object.
+// The MutateFn is called regardless of creating or updating an object. It
+// may be nil, in which case the object is only created if it doesn't exist.
 //
 // It returns the executed operation and an error.
 func CreateOrUpdate(ctx context.Context, c client.Client, obj client.Object, f MutateFn) (OperationResult, error) {
@@ -228,6 +287,12 @@ func CreateOrUpdate(ctx context.Context, c client.Client, obj client.Object, f M
 //
 // The MutateFn is called regardless of creating or updating an object.
 //
+// Unlike CreateOrUpdate, existing objects are changed using merge patches
+// which only contain the changes made by the MutateFn, which reduces
+// conflicts with concurrent writers.  Changes to the object and to its
+// status are sent separately, the latter to the status subresource, and only
+// if there are any.
+//
 // It returns the executed operation and an error.
 func CreateOrPatch(ctx context.Context, c client.Client, obj client.Object, f MutateFn) (OperationResult, error) {
 	key := client.ObjectKeyFromObject(obj)
@@ -235,10 +300,8 @@ func CreateOrPatch(ctx context.Context, c client.Client, obj client.Object, f Mu
 		if !apierrors.IsNotFound(err) {
 			return OperationResultNone, err
 		}
-		if f != nil {
-			if err := mutate(f, key, obj); err != nil {
-				return OperationResultNone, err
-			}
+		if err := mutate(f, key, obj); err != nil {
+			return OperationResultNone, err
 		}
 		if err := c.Create(ctx, obj); err != nil {
 			return OperationResultNone, err
@@ -270,10 +333,8 @@ func CreateOrPatch(ctx context.Context, c client.Client, obj client.Object, f Mu
 	}
 
 	// Mutate the original object.
-	if f != nil {
-		if err := mutate(f, key, obj); err != nil {
-			return OperationResultNone, err
-		}
+	if err := mutate(f, key, obj); err != nil {
+		return OperationResultNone, err
 	}
 
 	// Convert the resource to unstructured to compare against our before copy.
@@ -334,8 +395,12 @@ func CreateOrPatch(ctx context.Context, c client.Client, obj client.Object, f Mu
 	return result, nil
 }
 
-// mutate wraps a MutateFn and applies validation to its result.
+// mutate wraps a MutateFn and applies validation to its result.  A nil
+// MutateFn leaves the object as is.
 func mutate(f MutateFn, key client.ObjectKey, obj client.Object) error {
+	if f == nil {
+		return nil
+	}
 	if err := f(); err != nil {
 		return err
 	}
@@ -349,26 +414,34 @@ func mutate(f MutateFn, key client.ObjectKey, obj client.Object) error {
 type MutateFn func() error
 
 // AddFinalizer accepts an Object and adds the provided finalizer if not present.
-func AddFinalizer(o client.Object, finalizer string) {
+// It returns an indication of whether it updated the object's list of finalizers.
+func AddFinalizer(o client.Object, finalizer string) (finalizersUpdated bool) {
 	f := o.GetFinalizers()
 	for _, e := range f {
 		if e == finalizer {
-			return
+			return false
 		}
 	}
 	o.SetFinalizers(append(f, finalizer))
+	return true
 }
 
 // RemoveFinalizer accepts an Object and removes the provided finalizer if present.
-func RemoveFinalizer(o client.Object, finalizer string) {
+// It returns an indication of whether it updated the object's list of finalizers.
+func RemoveFinalizer(o client.Object, finalizer string) (finalizersUpdated bool) {
 	f := o.GetFinalizers()
-	for i := 0; i < len(f); i++ {
+	length := len(f)
+
+	index := 0
+	for i := 0; i < length; i++ {
 		if f[i] == finalizer {
-			f = append(f[:i], f[i+1:]...)
-			i--
+			continue
 		}
+		f[index] = f[i]
+		index++
 	}
-	o.SetFinalizers(f)
+	o.SetFinalizers(f[:index])
+	return length != index
 }
 
 // ContainsFinalizer checks an Object that the provided finalizer is present.
@@ -105,6 +105,78 @@ var _ = Describe("Controllerutil", func() {
 		})
 	})
 
+	Describe("RemoveOwnerReference", func() {
+		It("should remove the owner reference and keep the other ones", func() {
+			rs := &appsv1.ReplicaSet{}
+			dep := &extensionsv1beta1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", UID: "foo-uid"},
+			}
+			other := &extensionsv1beta1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "bar", UID: "bar-uid"},
+			}
+			Expect(controllerutil.SetOwnerReference(dep, rs, scheme.Scheme)).To(Succeed())
+			Expect(controllerutil.SetOwnerReference(other, rs, scheme.Scheme)).To(Succeed())
+
+			Expect(controllerutil.RemoveOwnerReference(dep, rs, scheme.Scheme)).To(Succeed())
+			Expect(rs.OwnerReferences).To(ConsistOf(metav1.OwnerReference{
+				Name:       "bar",
+				Kind:       "Deployment",
+				APIVersion: "extensions/v1beta1",
+				UID:        "bar-uid",
+			}))
+		})
+
+		It("should return an error if there is no owner reference to the owner", func() {
+			rs := &appsv1.ReplicaSet{}
+			dep := &extensionsv1beta1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", UID: "foo-uid"},
+			}
+			Expect(controllerutil.RemoveOwnerReference(dep, rs, scheme.Scheme)).NotTo(Succeed())
+		})
+	})
+
+	Describe("HasOwnerReference", func() {
+		It("should check whether the owner reference is present", func() {
+			rs := &appsv1.ReplicaSet{}
+			dep := &extensionsv1beta1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", UID: "foo-uid"},
+			}
+			has, err := controllerutil.HasOwnerReference(dep, rs, scheme.Scheme)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(has).To(BeFalse())
+
+			Expect(controllerutil.SetControllerReference(dep, rs, scheme.Scheme)).To(Succeed())
+			has, err = controllerutil.HasOwnerReference(dep, rs, scheme.Scheme)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(has).To(BeTrue())
+		})
+
+		It("should return an error if it can't find the group version kind of the owner", func() {
+			rs := &appsv1.ReplicaSet{}
+			dep := &extensionsv1beta1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", UID: "foo-uid"},
+			}
+			_, err := controllerutil.HasOwnerReference(dep, rs, runtime.NewScheme())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("HasControllerReference", func() {
+		It("should only be true for objects with a controller reference", func() {
+			rs := &appsv1.ReplicaSet{}
+			dep := &extensionsv1beta1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", UID: "foo-uid"},
+			}
+			Expect(controllerutil.HasControllerReference(rs)).To(BeFalse())
+
+			Expect(controllerutil.SetOwnerReference(dep, rs, scheme.Scheme)).To(Succeed())
+			Expect(controllerutil.HasControllerReference(rs)).To(BeFalse())
+
+			Expect(controllerutil.SetControllerReference(dep, rs, scheme.Scheme)).To(Succeed())
+			Expect(controllerutil.HasControllerReference(rs)).To(BeTrue())
+		})
+	})
+
 	Describe("SetControllerReference", func() {
 		It("should set the OwnerReference if it can find the group version kind", func() {
 			rs := &appsv1.ReplicaSet{}
@@ -353,6 +425,20 @@ var _ = Describe("Controllerutil", func() {
 			Expect(op).To(BeEquivalentTo(controllerutil.OperationResultNone))
 		})
 
+		It("creates and leaves existing objects as is without a MutateFn", func() {
+			deploy.Spec = deplSpec
+			op, err := controllerutil.CreateOrUpdate(context.TODO(), c, deploy, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(op).To(BeEquivalentTo(controllerutil.OperationResultCreated))
+
+			op, err = controllerutil.CreateOrUpdate(context.TODO(), c, deploy, nil)
+			By("returning no error")
+			Expect(err).NotTo(HaveOccurred())
+
+			By("returning OperationResultNone")
+			Expect(op).To(BeEquivalentTo(controllerutil.OperationResultNone))
+		})
+
 		It("errors when MutateFn changes object name on creation", func() {
 			op, err := controllerutil.CreateOrUpdate(context.TODO(), c, deploy, func() error {
 				Expect(specr()).To(Succeed())
@@ -677,25 +763,32 @@ var _ = Describe("Controllerutil", func() {
 			}
 
 			It("should add the finalizer when not present", func() {
-				controllerutil.AddFinalizer(deploy, testFinalizer)
+				Expect(controllerutil.AddFinalizer(deploy, testFinalizer)).To(BeTrue())
 				Expect(deploy.ObjectMeta.GetFinalizers()).To(Equal([]string{testFinalizer}))
 			})
 
 			It("should not add the finalizer when already present", func() {
-				controllerutil.AddFinalizer(deploy, testFinalizer)
+				Expect(controllerutil.AddFinalizer(deploy, testFinalizer)).To(BeFalse())
 				Expect(deploy.ObjectMeta.GetFinalizers()).To(Equal([]string{testFinalizer}))
 			})
 		})
 
 		Describe("RemoveFinalizer", func() {
 			It("should remove finalizer if present", func() {
-				controllerutil.RemoveFinalizer(deploy, testFinalizer)
+				Expect(controllerutil.RemoveFinalizer(deploy, testFinalizer)).To(BeTrue())
+				Expect(deploy.ObjectMeta.GetFinalizers()).To(Equal([]string{}))
+			})
+
+			It("should not change the finalizers if not present", func() {
+				Expect(controllerutil.RemoveFinalizer(deploy, testFinalizer)).To(BeFalse())
 				Expect(deploy.ObjectMeta.GetFinalizers()).To(Equal([]string{}))
 			})
 
 			It("should remove all equal finalizers if present", func() {
-				deploy.SetFinalizers(append(deploy.Finalizers, testFinalizer, testFinalizer))
-				controllerutil.RemoveFinalizer(deploy, testFinalizer)
+				deploy.SetFinalizers(append(deploy.Finalizers, testFinalizer, "other", testFinalizer))
+				Expect(controllerutil.RemoveFinalizer(deploy, testFinalizer)).To(BeTrue())
+				Expect(deploy.ObjectMeta.GetFinalizers()).To(Equal([]string{"other"}))
+				Expect(controllerutil.RemoveFinalizer(deploy, "other")).To(BeTrue())
 				Expect(deploy.ObjectMeta.GetFinalizers()).To(Equal([]string{}))
 			})
 		})
@@ -73,3 +73,33 @@ func ExampleCreateOrUpdate() {
 		log.Info("Deployment successfully reconciled", "operation", op)
 	}
 }
+
+// This example creates or patches an existing deployment, including its status.
+func ExampleCreateOrPatch() {
+	// c is client.Client
+
+	// Create or Patch the deployment default/foo
+	deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+
+	op, err := controllerutil.CreateOrPatch(context.TODO(), c, deploy, func() error {
+		// Only the fields changed here end up in the patches sent to the
+		// API server, so changes to other fields made concurrently by
+		// other clients are not overwritten.
+		if deploy.Labels == nil {
+			deploy.Labels = map[string]string{}
+		}
+		deploy.Labels["app"] = "foo"
+
+		// Changes to the status are sent in a separate patch to the status
+		// subresource.
+		deploy.Status.ObservedGeneration = deploy.Generation
+
+		return nil
+	})
+
+	if err != nil {
+		log.Error(err, "Deployment reconcile failed")
+	} else {
+		log.Info("Deployment successfully reconciled", "operation", op)
+	}
+}
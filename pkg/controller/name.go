@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var nameLock sync.Mutex
+var usedNames sets.String
+
+// validateName returns whether the uniqueness of the name of a controller with
+// the given options must be validated.
+func validateName(mgr manager.Manager, options Options) bool {
+	skip := options.SkipNameValidation
+	if skip == nil {
+		skip = mgr.GetControllerOptions().SkipNameValidation
+	}
+	return skip == nil || !*skip
+}
+
+// checkName records the given controller name, returning an error if a controller
+// with the same name has already been created in this process.
+func checkName(name string) error {
+	nameLock.Lock()
+	defer nameLock.Unlock()
+	if usedNames == nil {
+		usedNames = sets.NewString()
+	}
+
+	if usedNames.Has(name) {
+		return fmt.Errorf("controller with name %s already exists. Controller names must be unique to avoid multiple controllers reporting to the same metric. This validation can be disabled via the SkipNameValidation option", name)
+	}
+
+	usedNames.Insert(name)
+
+	return nil
+}
+
+// releaseName makes the given controller name available again, e.g. if the
+// controller couldn't be added to the manager.
+func releaseName(name string) {
+	nameLock.Lock()
+	defer nameLock.Unlock()
+	usedNames.Delete(name)
+}
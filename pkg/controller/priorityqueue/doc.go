@@ -0,0 +1,31 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package priorityqueue provides a workqueue.RateLimitingInterface implementation
+that hands out items in order of their priority.
+
+Items added with a higher priority are returned from Get before items with a lower
+priority, items with the same priority are returned in the order they were added.
+Like the client-go workqueue, an item is never handed out to more than one worker
+at a time and adding an item that is already queued does not queue it a second time.
+Named queues export the same workqueue metrics as the client-go workqueue.
+
+The event handlers in the handler package enqueue events observed during the initial
+list of an informer and periodic resyncs with LowPriority, so that changes made by
+users are reconciled before the backlog created by controller startup.
+*/
+package priorityqueue
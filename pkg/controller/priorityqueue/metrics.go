@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorityqueue
+
+import (
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+// unfinishedWorkUpdatePeriod is the period with which the unfinished work
+// metrics are updated, it matches the client-go workqueue.
+const unfinishedWorkUpdatePeriod = 500 * time.Millisecond
+
+// queueMetrics records the same metrics for a queue as the client-go workqueue
+// does. Its methods must be called with the lock of the queue held, and are
+// no-ops on a nil queueMetrics, which is used for unnamed queues.
+type queueMetrics struct {
+	depth                   workqueue.GaugeMetric
+	adds                    workqueue.CounterMetric
+	latency                 workqueue.HistogramMetric
+	workDuration            workqueue.HistogramMetric
+	unfinishedWorkSeconds   workqueue.SettableGaugeMetric
+	longestRunningProcessor workqueue.SettableGaugeMetric
+	retries                 workqueue.CounterMetric
+
+	// readyTimes holds when the items in the ready heap became ready,
+	// processingStartTimes when the items that are being processed were
+	// handed out.
+	readyTimes           map[interface{}]time.Time
+	processingStartTimes map[interface{}]time.Time
+}
+
+func newQueueMetrics(name string, provider workqueue.MetricsProvider) *queueMetrics {
+	if name == "" {
+		return nil
+	}
+	return &queueMetrics{
+		depth:                   provider.NewDepthMetric(name),
+		adds:                    provider.NewAddsMetric(name),
+		latency:                 provider.NewLatencyMetric(name),
+		workDuration:            provider.NewWorkDurationMetric(name),
+		unfinishedWorkSeconds:   provider.NewUnfinishedWorkSecondsMetric(name),
+		longestRunningProcessor: provider.NewLongestRunningProcessorSecondsMetric(name),
+		retries:                 provider.NewRetriesMetric(name),
+		readyTimes:              map[interface{}]time.Time{},
+		processingStartTimes:    map[interface{}]time.Time{},
+	}
+}
+
+// add is called when an item that isn't queued yet is added.
+func (m *queueMetrics) add() {
+	if m == nil {
+		return
+	}
+	m.adds.Inc()
+}
+
+// ready is called when an item is pushed onto the ready heap.
+func (m *queueMetrics) ready(key interface{}) {
+	if m == nil {
+		return
+	}
+	m.depth.Inc()
+	if _, exists := m.readyTimes[key]; !exists {
+		m.readyTimes[key] = time.Now()
+	}
+}
+
+// get is called when an item is handed out.
+func (m *queueMetrics) get(key interface{}) {
+	if m == nil {
+		return
+	}
+	m.depth.Dec()
+	m.processingStartTimes[key] = time.Now()
+	if readyTime, exists := m.readyTimes[key]; exists {
+		m.latency.Observe(time.Since(readyTime).Seconds())
+		delete(m.readyTimes, key)
+	}
+}
+
+// done is called when the processing of an item finished.
+func (m *queueMetrics) done(key interface{}) {
+	if m == nil {
+		return
+	}
+	if startTime, exists := m.processingStartTimes[key]; exists {
+		m.workDuration.Observe(time.Since(startTime).Seconds())
+		delete(m.processingStartTimes, key)
+	}
+}
+
+// retry is called when an item is added rate limited.
+func (m *queueMetrics) retry() {
+	if m == nil {
+		return
+	}
+	m.retries.Inc()
+}
+
+func (m *queueMetrics) updateUnfinishedWork() {
+	if m == nil {
+		return
+	}
+	var total float64
+	var oldest float64
+	for _, startTime := range m.processingStartTimes {
+		age := time.Since(startTime).Seconds()
+		total += age
+		if age > oldest {
+			oldest = age
+		}
+	}
+	m.unfinishedWorkSeconds.Set(total)
+	m.longestRunningProcessor.Set(oldest)
+}
@@ -0,0 +1,346 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorityqueue
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+)
+
+// LowPriority is the priority used by the event handlers for events
+// that are not the result of a change to an object, e.g. events that
+// are observed during the initial list of an informer or on resync.
+const LowPriority = -100
+
+// AddOpts describes the options for adding items to the queue.
+type AddOpts struct {
+	// After is the duration after which the item becomes ready.
+	After time.Duration
+	// RateLimited makes the queue consult its rate limiter to determine
+	// when the item becomes ready. It takes precedence over After.
+	RateLimited bool
+	// Priority is the priority of the item. Items with a higher priority
+	// are handed out first. Defaults to 0.
+	Priority int
+}
+
+// PriorityQueue is a workqueue.RateLimitingInterface that allows adding
+// items with a priority.
+type PriorityQueue interface {
+	workqueue.RateLimitingInterface
+	// AddWithOpts adds the given items to the queue using the given options.
+	// If an item is already queued, its priority is raised to the given
+	// priority and it becomes ready at the earliest of both ready times.
+	AddWithOpts(o AddOpts, items ...interface{})
+}
+
+// New constructs a new PriorityQueue with the given name that uses the given
+// rate limiter for AddRateLimited, Forget and NumRequeues. Like the client-go
+// workqueue, a named queue exports the workqueue metrics to the metrics.Registry.
+func New(name string, rateLimiter ratelimiter.RateLimiter) PriorityQueue {
+	return newWithMetricsProvider(name, rateLimiter, metrics.WorkqueueMetricsProvider{})
+}
+
+func newWithMetricsProvider(name string, rateLimiter ratelimiter.RateLimiter, provider workqueue.MetricsProvider) *priorityqueue {
+	pq := &priorityqueue{
+		name:        name,
+		rateLimiter: rateLimiter,
+		items:       map[interface{}]*item{},
+		locked:      map[interface{}]struct{}{},
+		metrics:     newQueueMetrics(name, provider),
+		stopCh:      make(chan struct{}),
+	}
+	pq.cond = sync.NewCond(&pq.lock)
+	if pq.metrics != nil {
+		go pq.updateUnfinishedWorkLoop()
+	}
+	return pq
+}
+
+var _ PriorityQueue = &priorityqueue{}
+
+type priorityqueue struct {
+	name        string
+	rateLimiter ratelimiter.RateLimiter
+
+	lock sync.Mutex
+	cond *sync.Cond
+
+	// items contains all items that are currently in the queue, regardless
+	// of whether they are ready.
+	items map[interface{}]*item
+	// ready contains the items that are ready to be handed out.
+	ready itemHeap
+	// locked contains the items that are currently being processed.
+	locked map[interface{}]struct{}
+
+	// addCounter is used to hand out items of the same priority in the
+	// order in which they were added.
+	addCounter   uint64
+	shuttingDown bool
+
+	// metrics is nil if the queue has no name.
+	metrics *queueMetrics
+	// stopCh is closed on ShutDown.
+	stopCh chan struct{}
+}
+
+type item struct {
+	key      interface{}
+	priority int
+	added    uint64
+	// readyAt is set while the item is waiting to become ready.
+	readyAt *time.Time
+	// blocked is set when the item became ready while it was still being
+	// processed, it will be made ready once Done is called for it.
+	blocked bool
+	// index is the index of the item in the ready heap, -1 if it is not in it.
+	index int
+}
+
+// AddWithOpts implements PriorityQueue.
+func (w *priorityqueue) AddWithOpts(o AddOpts, items ...interface{}) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.shuttingDown {
+		return
+	}
+
+	for _, key := range items {
+		after := o.After
+		if o.RateLimited {
+			after = w.rateLimiter.When(key)
+			w.metrics.retry()
+		}
+
+		var readyAt *time.Time
+		if after > 0 {
+			t := time.Now().Add(after)
+			readyAt = &t
+		}
+
+		existing, exists := w.items[key]
+		if !exists {
+			w.metrics.add()
+			w.addCounter++
+			it := &item{key: key, priority: o.Priority, added: w.addCounter, readyAt: readyAt, index: -1}
+			w.items[key] = it
+			if readyAt == nil {
+				w.makeReady(it)
+			} else {
+				w.scheduleReady(key, after)
+			}
+			continue
+		}
+
+		if o.Priority > existing.priority {
+			existing.priority = o.Priority
+			if existing.index >= 0 {
+				heap.Fix(&w.ready, existing.index)
+			}
+		}
+
+		if existing.readyAt == nil {
+			continue
+		}
+		switch {
+		case readyAt == nil:
+			existing.readyAt = nil
+			w.makeReady(existing)
+		case readyAt.Before(*existing.readyAt):
+			existing.readyAt = readyAt
+			w.scheduleReady(key, after)
+		}
+	}
+}
+
+// makeReady pushes the item onto the ready heap, unless it is currently
+// being processed. Must be called with the lock held.
+func (w *priorityqueue) makeReady(it *item) {
+	if _, isLocked := w.locked[it.key]; isLocked {
+		it.blocked = true
+		return
+	}
+	heap.Push(&w.ready, it)
+	w.metrics.ready(it.key)
+	w.cond.Signal()
+}
+
+func (w *priorityqueue) scheduleReady(key interface{}, after time.Duration) {
+	time.AfterFunc(after, func() {
+		w.lock.Lock()
+		defer w.lock.Unlock()
+
+		it, exists := w.items[key]
+		if !exists || it.readyAt == nil || time.Now().Before(*it.readyAt) {
+			// The item was already handed out, made ready by another
+			// add or there is a later timer for it.
+			return
+		}
+		it.readyAt = nil
+		w.makeReady(it)
+	})
+}
+
+// Add implements workqueue.Interface.
+func (w *priorityqueue) Add(item interface{}) {
+	w.AddWithOpts(AddOpts{}, item)
+}
+
+// AddAfter implements workqueue.DelayingInterface.
+func (w *priorityqueue) AddAfter(item interface{}, duration time.Duration) {
+	w.AddWithOpts(AddOpts{After: duration}, item)
+}
+
+// AddRateLimited implements workqueue.RateLimitingInterface.
+func (w *priorityqueue) AddRateLimited(item interface{}) {
+	w.AddWithOpts(AddOpts{RateLimited: true}, item)
+}
+
+// Get implements workqueue.Interface. Like the client-go workqueue, it keeps
+// handing out ready items after ShutDown was called until there are none left.
+func (w *priorityqueue) Get() (interface{}, bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for w.ready.Len() == 0 && !w.shuttingDown {
+		w.cond.Wait()
+	}
+	if w.ready.Len() == 0 {
+		return nil, true
+	}
+
+	it := heap.Pop(&w.ready).(*item)
+	delete(w.items, it.key)
+	w.locked[it.key] = struct{}{}
+	w.metrics.get(it.key)
+	return it.key, false
+}
+
+// Done implements workqueue.Interface.
+func (w *priorityqueue) Done(key interface{}) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	delete(w.locked, key)
+	w.metrics.done(key)
+	if it, exists := w.items[key]; exists && it.blocked {
+		it.blocked = false
+		w.makeReady(it)
+	}
+}
+
+// Forget implements workqueue.RateLimitingInterface.
+func (w *priorityqueue) Forget(item interface{}) {
+	w.rateLimiter.Forget(item)
+}
+
+// NumRequeues implements workqueue.RateLimitingInterface.
+func (w *priorityqueue) NumRequeues(item interface{}) int {
+	return w.rateLimiter.NumRequeues(item)
+}
+
+// Len implements workqueue.Interface. It returns the number of items
+// that are ready to be handed out.
+func (w *priorityqueue) Len() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.ready.Len()
+}
+
+// ShutDown implements workqueue.Interface.
+func (w *priorityqueue) ShutDown() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if !w.shuttingDown {
+		close(w.stopCh)
+	}
+	w.shuttingDown = true
+	w.cond.Broadcast()
+}
+
+// ShuttingDown implements workqueue.Interface.
+func (w *priorityqueue) ShuttingDown() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.shuttingDown
+}
+
+// updateUnfinishedWorkLoop periodically updates the metrics of the items that
+// are being processed until the queue is shut down.
+func (w *priorityqueue) updateUnfinishedWorkLoop() {
+	t := time.NewTicker(unfinishedWorkUpdatePeriod)
+	defer t.Stop()
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case <-t.C:
+			w.lock.Lock()
+			w.metrics.updateUnfinishedWork()
+			w.lock.Unlock()
+		}
+	}
+}
+
+func (w *priorityqueue) String() string {
+	return "priority queue: " + w.name
+}
+
+// itemHeap implements heap.Interface, ordering items by descending
+// priority and then by the order in which they were added.
+type itemHeap []*item
+
+func (h itemHeap) Len() int { return len(h) }
+
+func (h itemHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].added < h[j].added
+}
+
+func (h itemHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *itemHeap) Push(x interface{}) {
+	it := x.(*item)
+	it.index = len(*h)
+	*h = append(*h, it)
+}
+
+func (h *itemHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	old[n-1] = nil
+	it.index = -1
+	*h = old[:n-1]
+	return it
+}
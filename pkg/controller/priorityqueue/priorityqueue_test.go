@@ -0,0 +1,265 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorityqueue
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/workqueue"
+)
+
+var _ = Describe("PriorityQueue", func() {
+	var q PriorityQueue
+
+	BeforeEach(func() {
+		q = New("test", workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, time.Second))
+	})
+
+	AfterEach(func() {
+		q.ShutDown()
+	})
+
+	It("returns items in order of their priority", func() {
+		q.AddWithOpts(AddOpts{Priority: LowPriority}, "low")
+		q.Add("normal")
+		q.AddWithOpts(AddOpts{Priority: 10}, "high")
+		Expect(q.Len()).To(Equal(3))
+
+		for _, expected := range []string{"high", "normal", "low"} {
+			item, shutdown := q.Get()
+			Expect(shutdown).To(BeFalse())
+			Expect(item).To(Equal(expected))
+			q.Done(item)
+		}
+	})
+
+	It("returns items of the same priority in the order they were added", func() {
+		q.Add("first")
+		q.Add("second")
+		q.Add("third")
+
+		for _, expected := range []string{"first", "second", "third"} {
+			item, _ := q.Get()
+			Expect(item).To(Equal(expected))
+			q.Done(item)
+		}
+	})
+
+	It("deduplicates items and raises their priority", func() {
+		q.Add("other")
+		q.AddWithOpts(AddOpts{Priority: LowPriority}, "item")
+		q.AddWithOpts(AddOpts{Priority: 1}, "item")
+		Expect(q.Len()).To(Equal(2))
+
+		item, _ := q.Get()
+		Expect(item).To(Equal("item"))
+		q.Done(item)
+	})
+
+	It("does not lower the priority of a queued item", func() {
+		q.Add("other")
+		q.AddWithOpts(AddOpts{Priority: 1}, "item")
+		q.AddWithOpts(AddOpts{Priority: LowPriority}, "item")
+
+		item, _ := q.Get()
+		Expect(item).To(Equal("item"))
+		q.Done(item)
+	})
+
+	It("makes items added with After ready once the duration passed", func() {
+		q.AddAfter("item", 50*time.Millisecond)
+		Expect(q.Len()).To(Equal(0))
+
+		Eventually(q.Len).Should(Equal(1))
+		item, _ := q.Get()
+		Expect(item).To(Equal("item"))
+		q.Done(item)
+	})
+
+	It("makes a delayed item ready immediately if it is added without delay", func() {
+		q.AddAfter("item", time.Hour)
+		q.Add("item")
+		Expect(q.Len()).To(Equal(1))
+	})
+
+	It("does not hand out an item that is being processed", func() {
+		q.Add("item")
+		item, _ := q.Get()
+
+		q.Add("item")
+		Expect(q.Len()).To(Equal(0))
+
+		q.Done(item)
+		Expect(q.Len()).To(Equal(1))
+	})
+
+	It("uses the rate limiter for AddRateLimited", func() {
+		q.AddRateLimited("item")
+		Expect(q.NumRequeues("item")).To(Equal(1))
+		Eventually(q.Len).Should(Equal(1))
+
+		q.Forget("item")
+		Expect(q.NumRequeues("item")).To(Equal(0))
+	})
+
+	It("returns shutdown from Get once the queue is shut down and drained", func() {
+		q.Add("item")
+		q.ShutDown()
+		Expect(q.ShuttingDown()).To(BeTrue())
+
+		q.Add("ignored")
+		item, shutdown := q.Get()
+		Expect(shutdown).To(BeFalse())
+		Expect(item).To(Equal("item"))
+
+		_, shutdown = q.Get()
+		Expect(shutdown).To(BeTrue())
+	})
+
+	It("unblocks Get when ShutDown is called", func() {
+		done := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			defer close(done)
+			_, shutdown := q.Get()
+			Expect(shutdown).To(BeTrue())
+		}()
+		q.ShutDown()
+		Eventually(done).Should(BeClosed())
+	})
+
+	Describe("metrics", func() {
+		var provider *fakeMetricsProvider
+
+		BeforeEach(func() {
+			provider = &fakeMetricsProvider{}
+			q = newWithMetricsProvider("test", workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond, time.Second), provider)
+		})
+
+		It("records depth, adds, latency and work duration", func() {
+			q.Add("item")
+			q.Add("item")
+			q.AddAfter("delayed", time.Hour)
+			Expect(provider.adds.value()).To(Equal(2.0))
+			Expect(provider.depth.value()).To(Equal(1.0))
+
+			item, _ := q.Get()
+			Expect(provider.depth.value()).To(Equal(0.0))
+			Expect(provider.latency.observations()).To(Equal(1))
+
+			q.Done(item)
+			Expect(provider.workDuration.observations()).To(Equal(1))
+		})
+
+		It("records retries", func() {
+			q.AddRateLimited("item")
+			q.AddRateLimited("item")
+			Expect(provider.retries.value()).To(Equal(2.0))
+		})
+
+		It("records the unfinished work", func() {
+			q.Add("item")
+			_, _ = q.Get()
+			Eventually(provider.longestRunningProcessor.value).Should(BeNumerically(">", 0))
+			Eventually(provider.unfinishedWorkSeconds.value).Should(BeNumerically(">", 0))
+		})
+
+		It("does not record metrics for unnamed queues", func() {
+			q = newWithMetricsProvider("", workqueue.DefaultControllerRateLimiter(), provider)
+			q.Add("item")
+			Expect(provider.adds.value()).To(Equal(0.0))
+		})
+	})
+})
+
+// fakeMetricsProvider provides the same fakeMetric for all queues.
+type fakeMetricsProvider struct {
+	depth, adds, latency, workDuration, unfinishedWorkSeconds, longestRunningProcessor, retries fakeMetric
+}
+
+func (p *fakeMetricsProvider) NewDepthMetric(string) workqueue.GaugeMetric {
+	return &p.depth
+}
+
+func (p *fakeMetricsProvider) NewAddsMetric(string) workqueue.CounterMetric {
+	return &p.adds
+}
+
+func (p *fakeMetricsProvider) NewLatencyMetric(string) workqueue.HistogramMetric {
+	return &p.latency
+}
+
+func (p *fakeMetricsProvider) NewWorkDurationMetric(string) workqueue.HistogramMetric {
+	return &p.workDuration
+}
+
+func (p *fakeMetricsProvider) NewUnfinishedWorkSecondsMetric(string) workqueue.SettableGaugeMetric {
+	return &p.unfinishedWorkSeconds
+}
+
+func (p *fakeMetricsProvider) NewLongestRunningProcessorSecondsMetric(string) workqueue.SettableGaugeMetric {
+	return &p.longestRunningProcessor
+}
+
+func (p *fakeMetricsProvider) NewRetriesMetric(string) workqueue.CounterMetric {
+	return &p.retries
+}
+
+type fakeMetric struct {
+	mu       sync.Mutex
+	val      float64
+	observed int
+}
+
+func (m *fakeMetric) Inc() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.val++
+}
+
+func (m *fakeMetric) Dec() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.val--
+}
+
+func (m *fakeMetric) Set(v float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.val = v
+}
+
+func (m *fakeMetric) Observe(float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observed++
+}
+
+func (m *fakeMetric) value() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.val
+}
+
+func (m *fakeMetric) observations() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.observed
+}
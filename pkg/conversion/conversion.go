@@ -18,6 +18,31 @@ limitations under the License.
 Package conversion provides interface definitions that an API Type needs to
 implement for it to be supported by the generic conversion webhook handler
 defined under pkg/webhook/conversion.
+
+Conversions follow a hub-and-spoke model: one version of a Group/Kind is marked
+as the Hub, and every other version (a spoke) implements Convertible to convert
+to and from the Hub.  Converting between two spokes goes through the Hub, so
+supporting N versions takes 2(N-1) conversion functions instead of N(N-1).
+For example, with v2 as the Hub:
+
+	// Hub marks this type as a conversion hub.
+	func (*v2.CronJob) Hub() {}
+
+	// ConvertTo converts this CronJob to the Hub version (v2).
+	func (src *v1.CronJob) ConvertTo(dstRaw conversion.Hub) error {
+		dst := dstRaw.(*v2.CronJob)
+		dst.ObjectMeta = src.ObjectMeta
+		dst.Spec.Schedule = src.Spec.Schedule
+		return nil
+	}
+
+	// ConvertFrom converts from the Hub version (v2) to this version.
+	func (dst *v1.CronJob) ConvertFrom(srcRaw conversion.Hub) error {
+		src := srcRaw.(*v2.CronJob)
+		dst.ObjectMeta = src.ObjectMeta
+		dst.Spec.Schedule = src.Spec.Schedule
+		return nil
+	}
 */
 package conversion
 
@@ -30,6 +30,7 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -62,6 +63,11 @@ type CRDInstallOptions struct {
 	// CRDs is a list of CRDs to install
 	CRDs []apiextensionsv1.CustomResourceDefinition
 
+	// Objects is a list of API types to generate namespaced CRDs for, instead
+	// of reading them from Paths.  The types need to be registered in Scheme.
+	// See GenerateCRD for details and to generate cluster-scoped CRDs.
+	Objects []runtime.Object
+
 	// ErrorIfPathMissing will cause an error if a Path does not exist
 	ErrorIfPathMissing bool
 
@@ -96,6 +102,11 @@ func InstallCRDs(config *rest.Config, options CRDInstallOptions) ([]apiextension
 		return nil, fmt.Errorf("unable to read CRD files: %w", err)
 	}
 
+	// Generate CRDs for options.Objects
+	if err := generateCRDs(&options); err != nil {
+		return nil, fmt.Errorf("unable to generate CRDs: %w", err)
+	}
+
 	if err := modifyConversionWebhooks(options.CRDs, options.Scheme, options.WebhookOptions); err != nil {
 		return nil, err
 	}
@@ -139,11 +150,17 @@ func defaultCRDOptions(o *CRDInstallOptions) {
 	}
 }
 
-// WaitForCRDs waits for the CRDs to appear in discovery.
+// WaitForCRDs waits for the CRDs to appear in discovery. Named CRDs are
+// additionally required to report the Established condition.
 func WaitForCRDs(config *rest.Config, crds []apiextensionsv1.CustomResourceDefinition, options CRDInstallOptions) error {
 	// Add each CRD to a map of GroupVersion to Resource
 	waitingFor := map[schema.GroupVersion]*sets.String{}
+	waitingForEstablished := sets.NewString()
 	for _, crd := range crds {
+		if crd.GetName() != "" {
+			waitingForEstablished.Insert(crd.GetName())
+		}
+
 		gvs := []schema.GroupVersion{}
 		for _, version := range crd.Spec.Versions {
 			if version.Served {
@@ -163,7 +180,7 @@ func WaitForCRDs(config *rest.Config, crds []apiextensionsv1.CustomResourceDefin
 	}
 
 	// Poll until all resources are found in discovery
-	p := &poller{config: config, waitingFor: waitingFor}
+	p := &poller{config: config, waitingFor: waitingFor, waitingForEstablished: waitingForEstablished}
 	return wait.PollImmediate(options.PollInterval, options.MaxTime, p.poll)
 }
 
@@ -174,6 +191,10 @@ type poller struct {
 
 	// waitingFor is the map of resources keyed by group version that have not yet been found in discovery
 	waitingFor map[schema.GroupVersion]*sets.String
+
+	// waitingForEstablished is the set of CRD names that have not yet reported
+	// the Established condition
+	waitingForEstablished sets.String
 }
 
 // poll checks if all the resources have been found in discovery, and returns false if not.
@@ -209,9 +230,31 @@ func (p *poller) poll() (done bool, err error) {
 			allFound = false
 		}
 	}
+
+	for _, name := range p.waitingForEstablished.List() {
+		crd, err := cs.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil //nolint:nilerr
+		}
+		if !isEstablished(crd) {
+			allFound = false
+			continue
+		}
+		p.waitingForEstablished.Delete(name)
+	}
 	return allFound, nil
 }
 
+// isEstablished returns true if the CRD reports the Established condition.
+func isEstablished(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == apiextensionsv1.Established {
+			return cond.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // UninstallCRDs uninstalls a collection of CRDs by reading the crd yaml files from a directory.
 func UninstallCRDs(config *rest.Config, options CRDInstallOptions) error {
 	// Read the CRD yamls into options.CRDs
@@ -219,6 +262,12 @@ func UninstallCRDs(config *rest.Config, options CRDInstallOptions) error {
 		return err
 	}
 
+	// Generate CRDs for options.Objects
+	defaultCRDOptions(&options)
+	if err := generateCRDs(&options); err != nil {
+		return err
+	}
+
 	// Delete the CRDs from the apiserver
 	cs, err := client.New(config, client.Options{})
 	if err != nil {
@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+var (
+	typeOfTime        = reflect.TypeOf(metav1.Time{})
+	typeOfMicroTime   = reflect.TypeOf(metav1.MicroTime{})
+	typeOfDuration    = reflect.TypeOf(metav1.Duration{})
+	typeOfObjectMeta  = reflect.TypeOf(metav1.ObjectMeta{})
+	typeOfQuantity    = reflect.TypeOf(resource.Quantity{})
+	typeOfIntOrString = reflect.TypeOf(intstr.IntOrString{})
+	typeOfRawExt      = reflect.TypeOf(runtime.RawExtension{})
+)
+
+// GenerateCRD generates a CustomResourceDefinition for the given object,
+// which must be registered in the given scheme.  The OpenAPI schema of the
+// CRD is derived from the Go type of the object and its json tags, and a
+// status subresource is enabled if the type has a status field.
+//
+// The generated schema only describes the structure of the type: it contains
+// no validation, defaulting or printer columns.  It's meant to be used in
+// tests that don't want to depend on CRD manifests being regenerated, and is
+// not a replacement for a CRD generator like controller-gen.
+func GenerateCRD(s *runtime.Scheme, obj runtime.Object, scope apiextensionsv1.ResourceScope) (*apiextensionsv1.CustomResourceDefinition, error) {
+	gvk, err := apiutil.GVKForObject(obj, s)
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unable to generate CRD for %T: not a struct", obj)
+	}
+
+	schema := schemaForStruct(t, map[reflect.Type]bool{})
+	schema.Properties["apiVersion"] = apiextensionsv1.JSONSchemaProps{Type: "string"}
+	schema.Properties["kind"] = apiextensionsv1.JSONSchemaProps{Type: "string"}
+
+	version := apiextensionsv1.CustomResourceDefinitionVersion{
+		Name:    gvk.Version,
+		Served:  true,
+		Storage: true,
+		Schema:  &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: schema},
+	}
+	if _, hasStatus := schema.Properties["status"]; hasStatus {
+		version.Subresources = &apiextensionsv1.CustomResourceSubresources{
+			Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+		}
+	}
+
+	plural, singular := meta.UnsafeGuessKindToResource(gvk)
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: plural.Resource + "." + gvk.Group},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: gvk.Group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Kind:     gvk.Kind,
+				ListKind: gvk.Kind + "List",
+				Plural:   plural.Resource,
+				Singular: singular.Resource,
+			},
+			Scope:    scope,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{version},
+		},
+	}, nil
+}
+
+// generateCRDs generates CRDs for options.Objects and adds them to options.CRDs.
+func generateCRDs(options *CRDInstallOptions) error {
+	for _, obj := range options.Objects {
+		crd, err := GenerateCRD(options.Scheme, obj, apiextensionsv1.NamespaceScoped)
+		if err != nil {
+			return err
+		}
+		options.CRDs = append(options.CRDs, *crd)
+	}
+	return nil
+}
+
+// schemaForType returns the schema for the given type.  seen holds the
+// struct types currently being visited, to stop on recursive types.
+func schemaForType(t reflect.Type, seen map[reflect.Type]bool) *apiextensionsv1.JSONSchemaProps {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case typeOfTime, typeOfMicroTime:
+		return &apiextensionsv1.JSONSchemaProps{Type: "string", Format: "date-time"}
+	case typeOfDuration:
+		return &apiextensionsv1.JSONSchemaProps{Type: "string"}
+	case typeOfObjectMeta:
+		// metadata is validated by the API server itself, and CRD schemas
+		// may not describe more than its name and generateName.
+		return &apiextensionsv1.JSONSchemaProps{Type: "object"}
+	case typeOfQuantity, typeOfIntOrString:
+		return &apiextensionsv1.JSONSchemaProps{XIntOrString: true}
+	case typeOfRawExt:
+		return &apiextensionsv1.JSONSchemaProps{Type: "object", XPreserveUnknownFields: pointer.BoolPtr(true)}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &apiextensionsv1.JSONSchemaProps{Type: "string"}
+	case reflect.Bool:
+		return &apiextensionsv1.JSONSchemaProps{Type: "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64, reflect.Uint32:
+		return &apiextensionsv1.JSONSchemaProps{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &apiextensionsv1.JSONSchemaProps{Type: "integer", Format: "int32"}
+	case reflect.Float32, reflect.Float64:
+		return &apiextensionsv1.JSONSchemaProps{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &apiextensionsv1.JSONSchemaProps{Type: "string", Format: "byte"}
+		}
+		return &apiextensionsv1.JSONSchemaProps{
+			Type:  "array",
+			Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: schemaForType(t.Elem(), seen)},
+		}
+	case reflect.Map:
+		return &apiextensionsv1.JSONSchemaProps{
+			Type:                 "object",
+			AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{Allows: true, Schema: schemaForType(t.Elem(), seen)},
+		}
+	case reflect.Struct:
+		if seen[t] {
+			return &apiextensionsv1.JSONSchemaProps{Type: "object", XPreserveUnknownFields: pointer.BoolPtr(true)}
+		}
+		return schemaForStruct(t, seen)
+	default:
+		// interfaces and the like can hold anything.
+		return &apiextensionsv1.JSONSchemaProps{XPreserveUnknownFields: pointer.BoolPtr(true)}
+	}
+}
+
+// schemaForStruct returns the schema for the given struct type, following
+// the encoding/json rules for field names and embedded structs.
+func schemaForStruct(t reflect.Type, seen map[reflect.Type]bool) *apiextensionsv1.JSONSchemaProps {
+	seen[t] = true
+	defer delete(seen, t)
+
+	props := &apiextensionsv1.JSONSchemaProps{
+		Type:       "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{},
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := ""
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = strings.Split(tag, ",")[0]
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			// embedded structs are inlined, as with encoding/json
+			for propName, prop := range schemaForType(fieldType, seen).Properties {
+				props.Properties[propName] = prop
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		if name == "" {
+			name = field.Name
+		}
+		props.Properties[name] = *schemaForType(field.Type, seen)
+	}
+	return props
+}
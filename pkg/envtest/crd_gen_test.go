@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envtest
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var _ = Describe("GenerateCRD", func() {
+	var s *runtime.Scheme
+
+	BeforeEach(func() {
+		s = runtime.NewScheme()
+		s.AddKnownTypes(schema.GroupVersion{Group: "ship.example.com", Version: "v1"}, &Ferry{})
+	})
+
+	It("should generate a CRD for a registered type", func() {
+		crd, err := GenerateCRD(s, &Ferry{}, apiextensionsv1.ClusterScoped)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(crd.Name).To(Equal("ferries.ship.example.com"))
+		Expect(crd.Spec.Group).To(Equal("ship.example.com"))
+		Expect(crd.Spec.Scope).To(Equal(apiextensionsv1.ClusterScoped))
+		Expect(crd.Spec.Names).To(Equal(apiextensionsv1.CustomResourceDefinitionNames{
+			Kind:     "Ferry",
+			ListKind: "FerryList",
+			Plural:   "ferries",
+			Singular: "ferry",
+		}))
+		Expect(crd.Spec.Versions).To(HaveLen(1))
+		version := crd.Spec.Versions[0]
+		Expect(version.Name).To(Equal("v1"))
+		Expect(version.Served).To(BeTrue())
+		Expect(version.Storage).To(BeTrue())
+		Expect(version.Subresources).NotTo(BeNil())
+		Expect(version.Subresources.Status).NotTo(BeNil())
+	})
+
+	It("should derive the schema from the fields of the type", func() {
+		crd, err := GenerateCRD(s, &Ferry{}, apiextensionsv1.NamespaceScoped)
+		Expect(err).NotTo(HaveOccurred())
+
+		props := crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties
+		Expect(props).To(HaveKey("apiVersion"))
+		Expect(props).To(HaveKey("kind"))
+		Expect(props["metadata"].Type).To(Equal("object"))
+		Expect(props["metadata"].Properties).To(BeEmpty())
+
+		spec := props["spec"]
+		Expect(spec.Type).To(Equal("object"))
+		Expect(spec.Properties["capacity"].Type).To(Equal("integer"))
+		Expect(spec.Properties["capacity"].Format).To(Equal("int32"))
+		Expect(spec.Properties["route"].Type).To(Equal("array"))
+		Expect(spec.Properties["route"].Items.Schema.Type).To(Equal("string"))
+		Expect(spec.Properties["labels"].Type).To(Equal("object"))
+		Expect(spec.Properties["labels"].AdditionalProperties.Schema.Type).To(Equal("string"))
+		Expect(spec.Properties["port"].XIntOrString).To(BeTrue())
+		Expect(spec.Properties["departure"].Format).To(Equal("date-time"))
+		Expect(spec.Properties["name"].Type).To(Equal("string"), "embedded struct fields should be inlined")
+		Expect(spec.Properties).NotTo(HaveKey("ignored"))
+		Expect(spec.Properties).NotTo(HaveKey("internal"))
+
+		next := spec.Properties["next"]
+		Expect(next.Type).To(Equal("object"))
+		Expect(*next.XPreserveUnknownFields).To(BeTrue(), "recursive types should not be expanded")
+	})
+
+	It("should fail for types that are not registered in the scheme", func() {
+		_, err := GenerateCRD(runtime.NewScheme(), &Ferry{}, apiextensionsv1.NamespaceScoped)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+type FerryCommon struct {
+	Name string `json:"name"`
+}
+
+type FerrySpec struct {
+	FerryCommon `json:",inline"`
+
+	Capacity  int32              `json:"capacity"`
+	Route     []string           `json:"route,omitempty"`
+	Labels    map[string]string  `json:"labels,omitempty"`
+	Port      intstr.IntOrString `json:"port"`
+	Departure *metav1.Time       `json:"departure,omitempty"`
+	Next      *FerrySpec         `json:"next,omitempty"`
+	Ignored   string             `json:"-"`
+	internal  string             //nolint:unused,structcheck
+}
+
+type FerryStatus struct {
+	Docked bool `json:"docked"`
+}
+
+type Ferry struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FerrySpec   `json:"spec,omitempty"`
+	Status FerryStatus `json:"status,omitempty"`
+}
+
+func (f *Ferry) DeepCopyObject() runtime.Object {
+	out := *f
+	return &out
+}
@@ -17,17 +17,25 @@ limitations under the License.
 package envtest
 
 import (
+	"bytes"
 	"context"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -291,6 +299,67 @@ var _ = Describe("Test", func() {
 			Expect(crds).To(HaveLen(2))
 		}, 10)
 
+		It("should install CRDs from files and memory and wait for them to be established", func() {
+			crds, err = InstallCRDs(env.Config, CRDInstallOptions{
+				Paths: []string{filepath.Join(".", "testdata", "examplecrd_v1.yaml")},
+				CRDs: []apiextensionsv1.CustomResourceDefinition{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "sloops.ship.example.com"},
+						Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+							Group: "ship.example.com",
+							Scope: apiextensionsv1.NamespaceScoped,
+							Names: apiextensionsv1.CustomResourceDefinitionNames{
+								Kind:     "Sloop",
+								ListKind: "SloopList",
+								Plural:   "sloops",
+								Singular: "sloop",
+							},
+							Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+								{
+									Name:    "v1",
+									Storage: true,
+									Served:  true,
+									Schema: &apiextensionsv1.CustomResourceValidation{
+										OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"},
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(crds).To(HaveLen(2))
+
+			for _, installed := range crds {
+				crd := &apiextensionsv1.CustomResourceDefinition{}
+				Expect(c.Get(context.TODO(), types.NamespacedName{Name: installed.GetName()}, crd)).To(Succeed())
+				established := false
+				for _, cond := range crd.Status.Conditions {
+					if cond.Type == apiextensionsv1.Established {
+						established = cond.Status == apiextensionsv1.ConditionTrue
+					}
+				}
+				Expect(established).To(BeTrue(), "CRD %q is not established", crd.GetName())
+			}
+		}, 10)
+
+		It("should install CRDs generated from Go types", func() {
+			ferryScheme := runtime.NewScheme()
+			ferryScheme.AddKnownTypes(schema.GroupVersion{Group: "ship.example.com", Version: "v1"}, &Ferry{})
+
+			crds, err = InstallCRDs(env.Config, CRDInstallOptions{
+				Scheme:  ferryScheme,
+				Objects: []runtime.Object{&Ferry{}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(crds).To(HaveLen(1))
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(c.Get(context.TODO(), types.NamespacedName{Name: "ferries.ship.example.com"}, crd)).To(Succeed())
+			Expect(crd.Spec.Names.Kind).To(Equal("Ferry"))
+		}, 10)
+
 		It("should filter out already existent CRD", func() {
 			crds, err = InstallCRDs(env.Config, CRDInstallOptions{
 				Paths: []string{
@@ -939,6 +1008,18 @@ var _ = Describe("Test", func() {
 			Expect(env.Stop()).To(Succeed())
 		}, 30)
 
+		It("should stop the control plane when it fails to start completely", func() {
+			env := &Environment{ErrorIfCRDPathMissing: true, CRDDirectoryPaths: []string{invalidDirectory}}
+			cfg, err := env.Start()
+			Expect(err).To(HaveOccurred())
+			Expect(cfg).NotTo(BeNil())
+
+			cs, err := kubernetes.NewForConfig(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cs.Discovery().ServerVersion()
+			Expect(err).To(HaveOccurred())
+		}, 30)
+
 		It("should not raise an error on invalid dir when flag is disabled", func() {
 			env := &Environment{ErrorIfCRDPathMissing: false, CRDDirectoryPaths: []string{invalidDirectory}}
 			_, err := env.Start()
@@ -958,4 +1039,100 @@ var _ = Describe("Test", func() {
 			Expect(env.WebhookInstallOptions.LocalServingCertDir).ShouldNot(BeADirectory())
 		}, 30)
 	})
+
+	Describe("AddUserWithRules", func() {
+		It("should provision a user limited to the given rules", func() {
+			user, err := env.AddUserWithRules(User{Name: "configmap-reader"}, []rbacv1.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get", "list"},
+			}}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			cs, err := kubernetes.NewForConfig(user.Config())
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() error {
+				_, err := cs.CoreV1().ConfigMaps("default").List(context.TODO(), metav1.ListOptions{})
+				return err
+			}, 5*time.Second).Should(Succeed())
+
+			_, err = cs.CoreV1().Secrets("default").List(context.TODO(), metav1.ListOptions{})
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		})
+	})
+
+	Describe("ControlPlaneOutputDir", func() {
+		It("should write the control plane output to log files", func() {
+			dir, err := ioutil.TempDir("", "envtest-output-")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			env := &Environment{
+				ControlPlaneOutputDir: filepath.Join(dir, "logs"),
+				ControlPlane:          ControlPlane{Etcd: &Etcd{}},
+			}
+			apiServer := env.ControlPlane.GetAPIServer()
+			Expect(env.captureOutput(apiServer)).To(Succeed())
+			Expect(apiServer.Out).NotTo(BeNil())
+			Expect(apiServer.Err).To(BeIdenticalTo(apiServer.Out))
+			Expect(env.ControlPlane.Etcd.Out).NotTo(BeNil())
+
+			_, err = apiServer.Out.Write([]byte("apiserver output"))
+			Expect(err).NotTo(HaveOccurred())
+
+			env.closeOutputFiles()
+			Expect(apiServer.Out).To(BeNil())
+			Expect(apiServer.Err).To(BeNil())
+			Expect(env.ControlPlane.Etcd.Out).To(BeNil())
+
+			Expect(filepath.Join(dir, "logs", "etcd.log")).To(BeARegularFile())
+			contents, err := ioutil.ReadFile(filepath.Join(dir, "logs", "kube-apiserver.log"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("apiserver output"))
+		})
+
+		It("should not override configured output", func() {
+			dir, err := ioutil.TempDir("", "envtest-output-")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			out := &bytes.Buffer{}
+			env := &Environment{
+				ControlPlaneOutputDir: dir,
+				ControlPlane:          ControlPlane{Etcd: &Etcd{Out: out, Err: out}},
+			}
+			Expect(env.captureOutput(env.ControlPlane.GetAPIServer())).To(Succeed())
+			defer env.closeOutputFiles()
+
+			Expect(env.ControlPlane.Etcd.Out).To(BeIdenticalTo(out))
+			Expect(filepath.Join(dir, "etcd.log")).NotTo(BeAnExistingFile())
+		})
+	})
+
+	Describe("UseExistingCluster", func() {
+		It("should be enabled through the USE_EXISTING_CLUSTER environment variable", func() {
+			defer os.Unsetenv(envUseExistingCluster)
+
+			env := &Environment{}
+			for value, expected := range map[string]bool{"true": true, "1": true, "TRUE": true, "false": false, "": false, "nope": false} {
+				Expect(os.Setenv(envUseExistingCluster, value)).To(Succeed())
+				Expect(env.useExistingCluster()).To(Equal(expected), "value %q", value)
+			}
+		})
+
+		It("should prefer the UseExistingCluster field over the environment variable", func() {
+			defer os.Unsetenv(envUseExistingCluster)
+			Expect(os.Setenv(envUseExistingCluster, "true")).To(Succeed())
+
+			env := &Environment{UseExistingCluster: pointer.BoolPtr(false)}
+			Expect(env.useExistingCluster()).To(BeFalse())
+		})
+
+		It("should refuse to provision users", func() {
+			env := &Environment{UseExistingCluster: pointer.BoolPtr(true)}
+			_, err := env.AddUser(User{Name: "someone"}, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
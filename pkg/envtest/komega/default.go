@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package komega
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultK is the Komega used by the package global functions.
+var defaultK = &komega{ctx: context.Background()}
+
+// SetClient sets the client used by the package global functions.
+func SetClient(c client.Client) {
+	defaultK.client = c
+}
+
+// SetContext sets the context used by the package global functions.
+func SetContext(c context.Context) {
+	defaultK.ctx = c
+}
+
+func checkDefaultClient() {
+	if defaultK.client == nil {
+		panic("Default Komega's client is not set. Use SetClient to set it.")
+	}
+}
+
+// Get returns a function that fetches a resource and returns the occurring error.
+// It can be used with gomega.Eventually() like this
+//
+//	deployment := appsv1.Deployment{ ... }
+//	gomega.Eventually(komega.Get(&deployment)).Should(gomega.Succeed())
+//
+// By calling the returned function directly it can also be used with gomega.Expect(komega.Get(...)()).To(...)
+func Get(obj client.Object) func() error {
+	checkDefaultClient()
+	return defaultK.Get(obj)
+}
+
+// List returns a function that lists resources and returns the occurring error.
+// It can be used with gomega.Eventually() like this
+//
+//	deployments := v1.DeploymentList{ ... }
+//	gomega.Eventually(komega.List(&deployments)).Should(gomega.Succeed())
+//
+// By calling the returned function directly it can also be used as gomega.Expect(komega.List(...)()).To(...)
+func List(list client.ObjectList, opts ...client.ListOption) func() error {
+	checkDefaultClient()
+	return defaultK.List(list, opts...)
+}
+
+// Update returns a function that fetches a resource, applies the provided update function and then updates the resource.
+// It can be used with gomega.Eventually() like this:
+//
+//	deployment := appsv1.Deployment{ ... }
+//	gomega.Eventually(komega.Update(&deployment, func() {
+//	  deployment.Spec.Replicas = 3
+//	})).Should(gomega.Succeed())
+//
+// By calling the returned function directly it can also be used as gomega.Expect(komega.Update(...)()).To(...)
+func Update(obj client.Object, f func(), opts ...client.UpdateOption) func() error {
+	checkDefaultClient()
+	return defaultK.Update(obj, f, opts...)
+}
+
+// UpdateStatus returns a function that fetches a resource, applies the provided update function and then updates the resource's status.
+// It can be used with gomega.Eventually() like this:
+//
+//	deployment := appsv1.Deployment{ ... }
+//	gomega.Eventually(komega.UpdateStatus(&deployment, func() {
+//	  deployment.Status.AvailableReplicas = 1
+//	})).Should(gomega.Succeed())
+//
+// By calling the returned function directly it can also be used as gomega.Expect(komega.UpdateStatus(...)()).To(...)
+func UpdateStatus(obj client.Object, f func(), opts ...client.UpdateOption) func() error {
+	checkDefaultClient()
+	return defaultK.UpdateStatus(obj, f, opts...)
+}
+
+// Object returns a function that fetches a resource and returns the object.
+// It can be used with gomega.Eventually() like this:
+//
+//	deployment := appsv1.Deployment{ ... }
+//	gomega.Eventually(komega.Object(&deployment)).Should(gomega.WithTransform(func(d *appsv1.Deployment) int32 {
+//	  return d.Status.ReadyReplicas
+//	}, gomega.Equal(int32(3))))
+//
+// By calling the returned function directly it can also be used as gomega.Expect(komega.Object(...)()).To(...)
+func Object(obj client.Object) func() (client.Object, error) {
+	checkDefaultClient()
+	return defaultK.Object(obj)
+}
+
+// ObjectList returns a function that lists resources and returns the list.
+// It can be used with gomega.Eventually() like this:
+//
+//	deployments := appsv1.DeploymentList{ ... }
+//	gomega.Eventually(komega.ObjectList(&deployments)).Should(gomega.WithTransform(func(l *appsv1.DeploymentList) []appsv1.Deployment {
+//	  return l.Items
+//	}, gomega.HaveLen(1)))
+//
+// By calling the returned function directly it can also be used as gomega.Expect(komega.ObjectList(...)()).To(...)
+func ObjectList(list client.ObjectList, opts ...client.ListOption) func() (client.ObjectList, error) {
+	checkDefaultClient()
+	return defaultK.ObjectList(list, opts...)
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package komega
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func exampleDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: pointer.Int32Ptr(5),
+		},
+	}
+}
+
+var _ = Describe("Komega", func() {
+	var (
+		c client.Client
+		k Komega
+	)
+
+	BeforeEach(func() {
+		c = fake.NewClientBuilder().WithObjects(exampleDeployment()).Build()
+		k = New(c)
+	})
+
+	Describe("Get", func() {
+		It("should fetch the object", func() {
+			fetched := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+			Expect(k.Get(fetched)()).To(Succeed())
+			Expect(fetched.Spec.Replicas).To(Equal(pointer.Int32Ptr(5)))
+		})
+
+		It("should return the error if the object doesn't exist", func() {
+			missing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "missing"}}
+			err := k.Get(missing)()
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Describe("List", func() {
+		It("should list the objects", func() {
+			list := &appsv1.DeploymentList{}
+			Expect(k.List(list, client.InNamespace("default"))()).To(Succeed())
+			Expect(list.Items).To(HaveLen(1))
+		})
+	})
+
+	Describe("Update", func() {
+		It("should apply the update function and update the object", func() {
+			deployment := exampleDeployment()
+			Eventually(k.Update(deployment, func() {
+				deployment.Spec.Replicas = pointer.Int32Ptr(3)
+			})).Should(Succeed())
+
+			fetched := &appsv1.Deployment{}
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(deployment), fetched)).To(Succeed())
+			Expect(fetched.Spec.Replicas).To(Equal(pointer.Int32Ptr(3)))
+		})
+	})
+
+	Describe("UpdateStatus", func() {
+		It("should apply the update function and update the object's status", func() {
+			deployment := exampleDeployment()
+			Eventually(k.UpdateStatus(deployment, func() {
+				deployment.Status.AvailableReplicas = 1
+			})).Should(Succeed())
+
+			fetched := &appsv1.Deployment{}
+			Expect(c.Get(context.Background(), client.ObjectKeyFromObject(deployment), fetched)).To(Succeed())
+			Expect(fetched.Status.AvailableReplicas).To(Equal(int32(1)))
+		})
+	})
+
+	Describe("Object", func() {
+		It("should return the fetched object", func() {
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+			Eventually(k.Object(deployment)).Should(WithTransform(func(d *appsv1.Deployment) *int32 {
+				return d.Spec.Replicas
+			}, Equal(pointer.Int32Ptr(5))))
+		})
+	})
+
+	Describe("ObjectList", func() {
+		It("should return the fetched list", func() {
+			list := &appsv1.DeploymentList{}
+			Eventually(k.ObjectList(list)).Should(WithTransform(func(l *appsv1.DeploymentList) []appsv1.Deployment {
+				return l.Items
+			}, HaveLen(1)))
+		})
+	})
+
+	Describe("WithContext", func() {
+		It("should use the given context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			var seen context.Context
+			k = New(&contextRecordingClient{Client: c, seen: &seen}).WithContext(ctx)
+			_ = k.Get(&corev1.ConfigMap{})()
+			Expect(seen).To(Equal(ctx))
+		})
+	})
+
+	Describe("package functions", func() {
+		AfterEach(func() {
+			SetClient(nil)
+			SetContext(context.Background())
+		})
+
+		It("should use the client set with SetClient", func() {
+			SetClient(c)
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+			Expect(Get(deployment)()).To(Succeed())
+			Expect(deployment.Spec.Replicas).To(Equal(pointer.Int32Ptr(5)))
+		})
+
+		It("should panic if no client was set", func() {
+			Expect(func() { Get(&appsv1.Deployment{}) }).To(Panic())
+		})
+	})
+})
+
+// contextRecordingClient records the context of Get calls.
+type contextRecordingClient struct {
+	client.Client
+	seen *context.Context
+}
+
+func (c *contextRecordingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	*c.seen = ctx
+	return c.Client.Get(ctx, key, obj)
+}
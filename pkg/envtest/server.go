@@ -19,14 +19,18 @@ package envtest
 import (
 	"fmt"
 	"os"
-	"strings"
+	"path/filepath"
+	"strconv"
 	"time"
 
+	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
 	"sigs.k8s.io/controller-runtime/pkg/internal/testing/controlplane"
@@ -37,7 +41,8 @@ var log = logf.RuntimeLog.WithName("test-env")
 
 /*
 It's possible to override some defaults, by setting the following environment variables:
-	USE_EXISTING_CLUSTER (boolean): if set to true, envtest will use an existing cluster
+	USE_EXISTING_CLUSTER (boolean): if set to true, envtest will use an existing cluster, loading its configuration
+	  the same way as config.GetConfig (--kubeconfig, KUBECONFIG, in-cluster config or ~/.kube/config)
 	TEST_ASSET_KUBE_APISERVER (string): path to the api-server binary to use
 	TEST_ASSET_ETCD (string): path to the etcd binary to use
 	TEST_ASSET_KUBECTL (string): path to the kubectl binary to use
@@ -45,6 +50,7 @@ It's possible to override some defaults, by setting the following environment va
 	KUBEBUILDER_CONTROLPLANE_START_TIMEOUT (string supported by time.ParseDuration): timeout for test control plane to start. Defaults to 20s.
 	KUBEBUILDER_CONTROLPLANE_STOP_TIMEOUT (string supported by time.ParseDuration): timeout for test control plane to start. Defaults to 20s.
 	KUBEBUILDER_ATTACH_CONTROL_PLANE_OUTPUT (boolean): if set to true, the control plane's stdout and stderr are attached to os.Stdout and os.Stderr
+	KUBEBUILDER_CONTROL_PLANE_OUTPUT_DIR (string): directory the control plane's stdout and stderr are written to, one log file per process
 
 */
 const (
@@ -52,6 +58,7 @@ const (
 	envStartTimeout       = "KUBEBUILDER_CONTROLPLANE_START_TIMEOUT"
 	envStopTimeout        = "KUBEBUILDER_CONTROLPLANE_STOP_TIMEOUT"
 	envAttachOutput       = "KUBEBUILDER_ATTACH_CONTROL_PLANE_OUTPUT"
+	envOutputDir          = "KUBEBUILDER_CONTROL_PLANE_OUTPUT_DIR"
 	StartTimeout          = 60
 	StopTimeout           = 60
 
@@ -171,6 +178,17 @@ type Environment struct {
 	// Enable this to get more visibility of the testing control plane.
 	// It respect KUBEBUILDER_ATTACH_CONTROL_PLANE_OUTPUT environment variable.
 	AttachControlPlaneOutput bool
+
+	// ControlPlaneOutputDir is a directory the control plane's stdout and stderr
+	// are written to, in a kube-apiserver.log and an etcd.log file.  This is
+	// useful to keep the output around as a test artifact, e.g. to debug startup
+	// failures in CI.  It takes precedence over AttachControlPlaneOutput, and is
+	// ignored for processes that already have Out or Err set.
+	// It respects the KUBEBUILDER_CONTROL_PLANE_OUTPUT_DIR environment variable.
+	ControlPlaneOutputDir string
+
+	// outputFiles are the files opened for ControlPlaneOutputDir, closed on Stop.
+	outputFiles []*os.File
 }
 
 // Stop stops a running server.
@@ -191,11 +209,27 @@ func (te *Environment) Stop() error {
 		return nil
 	}
 
+	defer te.closeOutputFiles()
 	return te.ControlPlane.Stop()
 }
 
 // Start starts a local Kubernetes server and updates te.ApiserverPort with the port it is listening on.
+//
+// If the environment fails to start after the control plane was started, e.g. because
+// installing the CRDs failed, the control plane is stopped again so that no etcd or
+// kube-apiserver processes are left behind.
 func (te *Environment) Start() (*rest.Config, error) {
+	cfg, err := te.start()
+	if err != nil && !te.useExistingCluster() {
+		if stopErr := te.ControlPlane.Stop(); stopErr != nil {
+			log.Error(stopErr, "unable to stop the control plane after failing to start the environment")
+		}
+		te.closeOutputFiles()
+	}
+	return cfg, err
+}
+
+func (te *Environment) start() (*rest.Config, error) {
 	if te.useExistingCluster() {
 		log.V(1).Info("using existing cluster")
 		if te.Config == nil {
@@ -228,6 +262,10 @@ func (te *Environment) Start() (*rest.Config, error) {
 			te.ControlPlane.Etcd = &controlplane.Etcd{}
 		}
 
+		if err := te.captureOutput(apiServer); err != nil {
+			return nil, fmt.Errorf("unable to capture control plane output: %w", err)
+		}
+
 		if os.Getenv(envAttachOutput) == "true" {
 			te.AttachControlPlaneOutput = true
 		}
@@ -314,10 +352,66 @@ func (te *Environment) Start() (*rest.Config, error) {
 //
 // This is effectively a convinience alias for ControlPlane.AddUser -- see that
 // for more low-level details.
+//
+// Users can't be provisioned when using an existing cluster, since envtest
+// doesn't control its authentication setup.
 func (te *Environment) AddUser(user User, baseConfig *rest.Config) (*AuthenticatedUser, error) {
+	if te.useExistingCluster() {
+		return nil, fmt.Errorf("unable to provision user %q: users can't be added when using an existing cluster", user.Name)
+	}
 	return te.ControlPlane.AddUser(user, baseConfig)
 }
 
+// AddUserWithRules provisions a new user like AddUser, and grants it the given
+// RBAC rules cluster-wide through a ClusterRole and ClusterRoleBinding named
+// after the user.  The returned user can be used to test how controllers
+// behave with restricted permissions.
+//
+// Calling it again for the same user replaces its rules.
+//
+// The user is provisioned before the rules are granted, and isn't removed if
+// granting them fails: the returned error means that the user may exist but lack
+// some or all of the rules.  Calling AddUserWithRules again retries granting them.
+func (te *Environment) AddUserWithRules(user User, rules []rbacv1.PolicyRule, baseConfig *rest.Config) (*AuthenticatedUser, error) {
+	authUser, err := te.AddUser(user, baseConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := client.New(te.Config, client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client: %w", err)
+	}
+
+	name := "envtest:" + user.Name
+	role := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Rules:      rules,
+	}
+	if err := ensureCreated(cs, role); err != nil {
+		return nil, fmt.Errorf("unable to create ClusterRole for user %q: %w", user.Name, err)
+	}
+
+	binding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{{
+			APIGroup: rbacv1.GroupName,
+			Kind:     rbacv1.UserKind,
+			Name:     user.Name,
+		}},
+	}
+	if err := ensureCreated(cs, binding); err != nil {
+		return nil, fmt.Errorf("unable to create ClusterRoleBinding for user %q: %w", user.Name, err)
+	}
+
+	return authUser, nil
+}
+
 func (te *Environment) startControlPlane() error {
 	numTries, maxRetries := 0, 5
 	var err error
@@ -361,9 +455,88 @@ func (te *Environment) defaultTimeouts() error {
 	return nil
 }
 
+// captureOutput writes the output of the control plane processes without a
+// configured Out or Err to log files in ControlPlaneOutputDir, if set.
+func (te *Environment) captureOutput(apiServer *controlplane.APIServer) error {
+	if te.ControlPlaneOutputDir == "" {
+		te.ControlPlaneOutputDir = os.Getenv(envOutputDir)
+	}
+	if te.ControlPlaneOutputDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(te.ControlPlaneOutputDir, 0750); err != nil {
+		return err
+	}
+
+	if apiServer.Out == nil || apiServer.Err == nil {
+		f, err := te.openOutputFile("kube-apiserver.log")
+		if err != nil {
+			return err
+		}
+		if apiServer.Out == nil {
+			apiServer.Out = f
+		}
+		if apiServer.Err == nil {
+			apiServer.Err = f
+		}
+	}
+
+	etcd := te.ControlPlane.Etcd
+	if etcd.Out == nil || etcd.Err == nil {
+		f, err := te.openOutputFile("etcd.log")
+		if err != nil {
+			return err
+		}
+		if etcd.Out == nil {
+			etcd.Out = f
+		}
+		if etcd.Err == nil {
+			etcd.Err = f
+		}
+	}
+	return nil
+}
+
+func (te *Environment) openOutputFile(name string) (*os.File, error) {
+	f, err := os.OpenFile(filepath.Join(te.ControlPlaneOutputDir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+	te.outputFiles = append(te.outputFiles, f)
+	return f, nil
+}
+
+// closeOutputFiles closes the files opened by captureOutput, and detaches them
+// from the control plane processes so that a restart opens them again.
+func (te *Environment) closeOutputFiles() {
+	for _, f := range te.outputFiles {
+		if apiServer := te.ControlPlane.APIServer; apiServer != nil {
+			if apiServer.Out == f {
+				apiServer.Out = nil
+			}
+			if apiServer.Err == f {
+				apiServer.Err = nil
+			}
+		}
+		if etcd := te.ControlPlane.Etcd; etcd != nil {
+			if etcd.Out == f {
+				etcd.Out = nil
+			}
+			if etcd.Err == f {
+				etcd.Err = nil
+			}
+		}
+		if err := f.Close(); err != nil {
+			log.Error(err, "unable to close control plane output file", "file", f.Name())
+		}
+	}
+	te.outputFiles = nil
+}
+
 func (te *Environment) useExistingCluster() bool {
 	if te.UseExistingCluster == nil {
-		return strings.ToLower(os.Getenv(envUseExistingCluster)) == "true"
+		useExisting, _ := strconv.ParseBool(os.Getenv(envUseExistingCluster))
+		return useExisting
 	}
 	return *te.UseExistingCluster
 }
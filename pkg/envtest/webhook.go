@@ -20,6 +20,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	admissionv1 "k8s.io/api/admissionregistration/v1"
@@ -69,10 +70,11 @@ type WebhookInstallOptions struct {
 	// LocalServingHostExternalName is the hostname to use to reach the webhook server.
 	LocalServingHostExternalName string
 
-	// MaxTime is the max time to wait
+	// MaxTime is the max time to wait for the webhook configurations to be
+	// available. Defaults to 10 seconds.
 	MaxTime time.Duration
 
-	// PollInterval is the interval to check
+	// PollInterval is the interval to check. Defaults to 100 milliseconds.
 	PollInterval time.Duration
 }
 
@@ -105,7 +107,7 @@ func (o *WebhookInstallOptions) ModifyWebhookDefinitions() error {
 func updateClientConfig(cc *admissionv1.WebhookClientConfig, hostPort string, caData []byte) {
 	cc.CABundle = caData
 	if cc.Service != nil && cc.Service.Path != nil {
-		url := fmt.Sprintf("https://%s/%s", hostPort, *cc.Service.Path)
+		url := fmt.Sprintf("https://%s/%s", hostPort, strings.TrimPrefix(*cc.Service.Path, "/"))
 		cc.URL = &url
 		cc.Service = nil
 	}
@@ -146,6 +148,8 @@ func (o *WebhookInstallOptions) PrepWithoutInstalling() error {
 
 // Install installs specified webhooks to the API server.
 func (o *WebhookInstallOptions) Install(config *rest.Config) error {
+	defaultWebhookOptions(o)
+
 	if len(o.LocalServingCAData) == 0 {
 		if err := o.PrepWithoutInstalling(); err != nil {
 			return err
@@ -159,6 +163,16 @@ func (o *WebhookInstallOptions) Install(config *rest.Config) error {
 	return WaitForWebhooks(config, o.MutatingWebhooks, o.ValidatingWebhooks, *o)
 }
 
+// defaultWebhookOptions sets the default values for Webhooks.
+func defaultWebhookOptions(o *WebhookInstallOptions) {
+	if o.MaxTime == 0 {
+		o.MaxTime = defaultMaxWait
+	}
+	if o.PollInterval == 0 {
+		o.PollInterval = defaultPollInterval
+	}
+}
+
 // Cleanup cleans up cert directories.
 func (o *WebhookInstallOptions) Cleanup() error {
 	if o.LocalServingCertDir != "" {
@@ -172,6 +186,8 @@ func WaitForWebhooks(config *rest.Config,
 	mutatingWebhooks []admissionv1.MutatingWebhookConfiguration,
 	validatingWebhooks []admissionv1.ValidatingWebhookConfiguration,
 	options WebhookInstallOptions) error {
+	defaultWebhookOptions(&options)
+
 	waitingFor := map[schema.GroupVersionKind]*sets.String{}
 
 	for _, hook := range mutatingWebhooks {
@@ -310,7 +326,7 @@ func ensureCreated(cs client.Client, obj client.Object) error {
 	case err != nil:
 		return err
 	default:
-		log.V(1).Info("Webhook configuration already exists, updating", "webhook", obj.GetName())
+		log.V(1).Info("Object already exists, updating", "type", fmt.Sprintf("%T", obj), "name", obj.GetName())
 		obj.SetResourceVersion(existing.GetResourceVersion())
 		if err := cs.Update(context.Background(), obj); err != nil {
 			return err
@@ -108,6 +108,27 @@ var _ = Describe("Test", func() {
 			Expect(len(installOptions.MutatingWebhooks)).To(Equal(2))
 			Expect(len(installOptions.ValidatingWebhooks)).To(Equal(2))
 		})
+
+		It("should point service-based client configs at the local serving host", func() {
+			installOptions := WebhookInstallOptions{
+				Paths:              []string{filepath.Join("testdata", "webhooks", "manifests.yaml")},
+				LocalServingHost:   "127.0.0.1",
+				LocalServingPort:   9443,
+				LocalServingCAData: []byte("ca-data"),
+			}
+			Expect(parseWebhook(&installOptions)).To(Succeed())
+			Expect(installOptions.ModifyWebhookDefinitions()).To(Succeed())
+
+			cc := installOptions.MutatingWebhooks[0].Webhooks[0].ClientConfig
+			Expect(cc.Service).To(BeNil())
+			Expect(cc.URL).NotTo(BeNil())
+			Expect(*cc.URL).To(Equal("https://127.0.0.1:9443/mutate-v1"))
+			Expect(cc.CABundle).To(Equal([]byte("ca-data")))
+
+			cc = installOptions.ValidatingWebhooks[0].Webhooks[0].ClientConfig
+			Expect(cc.Service).To(BeNil())
+			Expect(*cc.URL).To(Equal("https://127.0.0.1:9443/validate-v1"))
+		})
 	})
 })
 
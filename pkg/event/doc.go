@@ -24,5 +24,9 @@ source.Sources and handler.EventHandlers.
 Events generally contain both a full runtime.Object that caused the event, as well
 as a direct handle to that object's metadata.  This saves a lot of typecasting in
 code that works with Events.
+
+Events for resources outside of the cluster, which have no Kubernetes object, can be
+created with NewExternalGenericEvent and emitted with e.g. a source.Channel.  They are
+marked as External, and may carry arbitrary Metadata describing the external resource.
 */
 package event
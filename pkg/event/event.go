@@ -16,13 +16,21 @@ limitations under the License.
 
 package event
 
-import "sigs.k8s.io/controller-runtime/pkg/client"
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
 
 // CreateEvent is an event where a Kubernetes object was created.  CreateEvent should be generated
 // by a source.Source and transformed into a reconcile.Request by an handler.EventHandler.
 type CreateEvent struct {
 	// Object is the object from the event
 	Object client.Object
+
+	// IsInInitialList is true if the Create event was observed before the source
+	// that emitted it finished its initial sync, e.g. because the object already
+	// existed when the controller started. This is determined on a best effort basis.
+	IsInInitialList bool
 }
 
 // UpdateEvent is an event where a Kubernetes object was updated.  UpdateEvent should be generated
@@ -49,7 +57,75 @@ type DeleteEvent struct {
 // GenericEvent is an event where the operation type is unknown (e.g. polling or event originating outside the cluster).
 // GenericEvent should be generated by a source.Source and transformed into a reconcile.Request by an
 // handler.EventHandler.
+//
+// Use NewExternalGenericEvent to create a GenericEvent for a resource outside of the cluster.
 type GenericEvent struct {
 	// Object is the object from the event
 	Object client.Object
+
+	// Metadata is arbitrary data attached to the event by its producer, e.g. the identity of
+	// an external resource.  It is passed through unchanged by handlers and predicates.
+	Metadata interface{}
+
+	// External is true if the event is about a resource outside of the cluster.  Object then
+	// only carries the namespace and name of the object that reconciles the external resource,
+	// and no other data (e.g. labels or owner references).
+	External bool
+}
+
+// NewExternalGenericEvent returns a GenericEvent for a resource outside of the cluster (e.g. a DNS record or
+// a cloud load balancer) that has no Kubernetes object.  The Object of the event is a PartialObjectMetadata
+// with the given namespace and name, which should identify the object that reconciles the external resource,
+// e.g. handler.EnqueueRequestForObject enqueues a Request for the namespace and name.  The given metadata is
+// set as the Metadata of the event.
+func NewExternalGenericEvent(namespace, name string, metadata interface{}) GenericEvent {
+	return GenericEvent{
+		Object: &metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+		},
+		Metadata: metadata,
+		External: true,
+	}
+}
+
+// TypedCreateEvent is a CreateEvent whose object is of a concrete type T.
+type TypedCreateEvent[T client.Object] struct {
+	// Object is the object from the event
+	Object T
+
+	// IsInInitialList is true if the Create event was observed before the source
+	// that emitted it finished its initial sync. See CreateEvent.IsInInitialList.
+	IsInInitialList bool
+}
+
+// TypedUpdateEvent is an UpdateEvent whose objects are of a concrete type T.
+type TypedUpdateEvent[T client.Object] struct {
+	// ObjectOld is the object from the event
+	ObjectOld T
+
+	// ObjectNew is the object from the event
+	ObjectNew T
+}
+
+// TypedDeleteEvent is a DeleteEvent whose object is of a concrete type T.
+type TypedDeleteEvent[T client.Object] struct {
+	// Object is the object from the event
+	Object T
+
+	// DeleteStateUnknown is true if the Delete event was missed but we identified the object
+	// as having been deleted.
+	DeleteStateUnknown bool
+}
+
+// TypedGenericEvent is a GenericEvent whose object is of a concrete type T.
+type TypedGenericEvent[T client.Object] struct {
+	// Object is the object from the event
+	Object T
+
+	// Metadata is arbitrary data attached to the event by its producer.
+	// See GenericEvent.Metadata.
+	Metadata interface{}
 }
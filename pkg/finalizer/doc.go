@@ -0,0 +1,36 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package finalizer provides a registry of independent finalizer
+implementations, keyed by the name of the finalizer they handle.
+
+A single call to Finalize adds all registered finalizers to objects that are
+not being deleted, and runs the registered finalizers of objects that are
+being deleted, removing the finalizers that succeeded.  The returned Result
+tells whether the object or its status need to be updated:
+
+	finalizers := finalizer.NewFinalizers()
+	if err := finalizers.Register("example.com/cleanup-dns", dnsFinalizer); err != nil {
+		return err
+	}
+	...
+	res, err := finalizers.Finalize(ctx, obj)
+	if res.Updated {
+		// update obj
+	}
+	if res.StatusUpdated {
+		// update obj's status
+	}
+*/
+package finalizer
@@ -16,6 +16,7 @@ package finalizer
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -53,9 +54,16 @@ func (f finalizers) Finalize(ctx context.Context, obj client.Object) (Result, er
 		errList []error
 	)
 	res.Updated = false
-	for key, finalizer := range f {
-		if dt := obj.GetDeletionTimestamp(); dt.IsZero() && !controllerutil.ContainsFinalizer(obj, key) {
-			controllerutil.AddFinalizer(obj, key)
+	// Process the finalizers in a stable order, so that they are added to
+	// and run on objects in the same order every time.
+	keys := make([]string, 0, len(f))
+	for key := range f {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		finalizer := f[key]
+		if dt := obj.GetDeletionTimestamp(); dt.IsZero() && controllerutil.AddFinalizer(obj, key) {
 			res.Updated = true
 		} else if !dt.IsZero() && controllerutil.ContainsFinalizer(obj, key) {
 			finalizerRes, err := finalizer.Finalize(ctx, obj)
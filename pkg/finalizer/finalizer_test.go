@@ -132,6 +132,21 @@ var _ = Describe("TestFinalizer", func() {
 			Expect(len(pod.Finalizers)).To(Equal(0))
 		})
 
+		It("should add multiple finalizers in a stable order", func() {
+			for _, key := range []string{"finalizers.sigs.k8s.io/b", "finalizers.sigs.k8s.io/c", "finalizers.sigs.k8s.io/a"} {
+				Expect(finalizers.Register(key, f)).To(Succeed())
+			}
+
+			result, err := finalizers.Finalize(context.TODO(), pod)
+			Expect(err).To(BeNil())
+			Expect(result.Updated).To(BeTrue())
+			Expect(pod.Finalizers).To(Equal([]string{
+				"finalizers.sigs.k8s.io/a",
+				"finalizers.sigs.k8s.io/b",
+				"finalizers.sigs.k8s.io/c",
+			}))
+		})
+
 		It("should return result as false and a non-nil error", func() {
 			now := metav1.Now()
 			pod.DeletionTimestamp = &now
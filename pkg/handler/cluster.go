@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+// WithClusterName wraps an EventHandler so that every reconcile.Request it enqueues carries the
+// given cluster name, unless the request already names a cluster.  It is meant for watches on a
+// secondary cluster, so that the Reconciler can tell which cluster the object lives in and pick the
+// matching client, e.g. from Manager.GetCluster:
+//
+//	ctrl.Watch(source.NewKindWithCache(&corev1.Pod{}, spoke.GetCache()),
+//		handler.WithClusterName("spoke-1", &handler.EnqueueRequestForObject{}))
+func WithClusterName(clusterName string, h EventHandler) EventHandler {
+	return &withClusterName{clusterName: clusterName, handler: h}
+}
+
+var _ EventHandler = &withClusterName{}
+
+type withClusterName struct {
+	clusterName string
+	handler     EventHandler
+}
+
+// Create implements EventHandler.
+func (w *withClusterName) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	w.handler.Create(ctx, evt, w.queue(q))
+}
+
+// Update implements EventHandler.
+func (w *withClusterName) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	w.handler.Update(ctx, evt, w.queue(q))
+}
+
+// Delete implements EventHandler.
+func (w *withClusterName) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	w.handler.Delete(ctx, evt, w.queue(q))
+}
+
+// Generic implements EventHandler.
+func (w *withClusterName) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	w.handler.Generic(ctx, evt, w.queue(q))
+}
+
+// InjectFunc implements inject.Injector.
+func (w *withClusterName) InjectFunc(f inject.Func) error {
+	if f == nil {
+		return nil
+	}
+	return f(w.handler)
+}
+
+// InjectLogger implements inject.Logger, so that the wrapped handler gets the logger of the controller.
+func (w *withClusterName) InjectLogger(l logr.Logger) error {
+	_, err := inject.LoggerInto(l, w.handler)
+	return err
+}
+
+// queue returns a queue that sets the cluster name on the requests added to q.
+// Priority queues stay priority queues, so that wrapped handlers can still set priorities.
+func (w *withClusterName) queue(q workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+	if pq, isPriorityQueue := q.(priorityqueue.PriorityQueue); isPriorityQueue {
+		return &clusterNamePriorityQueue{
+			PriorityQueue:    pq,
+			clusterNameQueue: clusterNameQueue{RateLimitingInterface: pq, clusterName: w.clusterName},
+		}
+	}
+	return &clusterNameQueue{RateLimitingInterface: q, clusterName: w.clusterName}
+}
+
+// clusterNameQueue is a queue that sets a cluster name on all reconcile.Requests added to it.
+type clusterNameQueue struct {
+	workqueue.RateLimitingInterface
+	clusterName string
+}
+
+func (q *clusterNameQueue) withClusterName(item interface{}) interface{} {
+	req, ok := item.(reconcile.Request)
+	if !ok || req.ClusterName != "" {
+		return item
+	}
+	req.ClusterName = q.clusterName
+	return req
+}
+
+// Add implements workqueue.Interface.
+func (q *clusterNameQueue) Add(item interface{}) {
+	q.RateLimitingInterface.Add(q.withClusterName(item))
+}
+
+// AddAfter implements workqueue.DelayingInterface.
+func (q *clusterNameQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.RateLimitingInterface.AddAfter(q.withClusterName(item), duration)
+}
+
+// AddRateLimited implements workqueue.RateLimitingInterface.
+func (q *clusterNameQueue) AddRateLimited(item interface{}) {
+	q.RateLimitingInterface.AddRateLimited(q.withClusterName(item))
+}
+
+// clusterNamePriorityQueue is a priority queue that sets a cluster name on all
+// reconcile.Requests added to it.
+type clusterNamePriorityQueue struct {
+	priorityqueue.PriorityQueue
+	clusterNameQueue clusterNameQueue
+}
+
+// Add implements workqueue.Interface.
+func (q *clusterNamePriorityQueue) Add(item interface{}) {
+	q.clusterNameQueue.Add(item)
+}
+
+// AddAfter implements workqueue.DelayingInterface.
+func (q *clusterNamePriorityQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.clusterNameQueue.AddAfter(item, duration)
+}
+
+// AddRateLimited implements workqueue.RateLimitingInterface.
+func (q *clusterNamePriorityQueue) AddRateLimited(item interface{}) {
+	q.clusterNameQueue.AddRateLimited(item)
+}
+
+// AddWithOpts implements priorityqueue.PriorityQueue.
+func (q *clusterNamePriorityQueue) AddWithOpts(o priorityqueue.AddOpts, items ...interface{}) {
+	withClusterName := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		withClusterName = append(withClusterName, q.clusterNameQueue.withClusterName(item))
+	}
+	q.PriorityQueue.AddWithOpts(o, withClusterName...)
+}
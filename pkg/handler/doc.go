@@ -34,5 +34,15 @@ to be reconciled.
 EnqueueRequestsFromMapFunc - Enqueues reconcile.Requests resulting from a user provided transformation function run against the
 object in the Event.  This will cause an arbitrary collection of objects (defined from a transformation of the
 source object) to be reconciled.
+
+EnqueueRequestsFromIndex - Enqueues reconcile.Requests for all objects that refer to the object in the Event via a
+field index.  This will cause all objects that refer to the source object, e.g. all objects scheduled to a Node, to
+be reconciled.
+
+For small customizations that are not covered by the premade event handlers, Funcs and TypedFuncs can be used to
+define an EventHandler inline from a set of functions, without declaring a new type.
+
+For watches on a secondary cluster, WithClusterName wraps any EventHandler so that the reconcile.Requests it
+enqueues name the cluster the object lives in.
 */
 package handler
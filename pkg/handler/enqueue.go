@@ -17,8 +17,11 @@ limitations under the License.
 package handler
 
 import (
+	"context"
+
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -36,25 +39,25 @@ var _ EventHandler = &EnqueueRequestForObject{}
 type EnqueueRequestForObject struct{}
 
 // Create implements EventHandler.
-func (e *EnqueueRequestForObject) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+func (e *EnqueueRequestForObject) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
 	if evt.Object == nil {
 		enqueueLog.Error(nil, "CreateEvent received with no metadata", "event", evt)
 		return
 	}
-	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+	addWithPriority(q, reconcile.Request{NamespacedName: types.NamespacedName{
 		Name:      evt.Object.GetName(),
 		Namespace: evt.Object.GetNamespace(),
-	}})
+	}}, createPriority(evt))
 }
 
 // Update implements EventHandler.
-func (e *EnqueueRequestForObject) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+func (e *EnqueueRequestForObject) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
 	switch {
 	case evt.ObjectNew != nil:
-		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+		addWithPriority(q, reconcile.Request{NamespacedName: types.NamespacedName{
 			Name:      evt.ObjectNew.GetName(),
 			Namespace: evt.ObjectNew.GetNamespace(),
-		}})
+		}}, updatePriority(evt))
 	case evt.ObjectOld != nil:
 		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
 			Name:      evt.ObjectOld.GetName(),
@@ -66,7 +69,7 @@ func (e *EnqueueRequestForObject) Update(evt event.UpdateEvent, q workqueue.Rate
 }
 
 // Delete implements EventHandler.
-func (e *EnqueueRequestForObject) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+func (e *EnqueueRequestForObject) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
 	if evt.Object == nil {
 		enqueueLog.Error(nil, "DeleteEvent received with no metadata", "event", evt)
 		return
@@ -78,7 +81,7 @@ func (e *EnqueueRequestForObject) Delete(evt event.DeleteEvent, q workqueue.Rate
 }
 
 // Generic implements EventHandler.
-func (e *EnqueueRequestForObject) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+func (e *EnqueueRequestForObject) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.RateLimitingInterface) {
 	if evt.Object == nil {
 		enqueueLog.Error(nil, "GenericEvent received with no metadata", "event", evt)
 		return
@@ -88,3 +91,33 @@ func (e *EnqueueRequestForObject) Generic(evt event.GenericEvent, q workqueue.Ra
 		Namespace: evt.Object.GetNamespace(),
 	}})
 }
+
+// createPriority returns the priority with which requests for the given
+// CreateEvent are added to a priority queue.
+func createPriority(evt event.CreateEvent) int {
+	if evt.IsInInitialList {
+		return priorityqueue.LowPriority
+	}
+	return 0
+}
+
+// updatePriority returns the priority with which requests for the given
+// UpdateEvent are added to a priority queue. Updates that didn't change the
+// resourceVersion are resyncs and get a low priority.
+func updatePriority(evt event.UpdateEvent) int {
+	if evt.ObjectOld != nil && evt.ObjectNew != nil &&
+		evt.ObjectOld.GetResourceVersion() == evt.ObjectNew.GetResourceVersion() {
+		return priorityqueue.LowPriority
+	}
+	return 0
+}
+
+// addWithPriority adds the item to the queue with the given priority if the
+// queue is a priority queue, and adds it normally otherwise.
+func addWithPriority(q workqueue.RateLimitingInterface, item interface{}, priority int) {
+	if pq, isPriorityQueue := q.(priorityqueue.PriorityQueue); isPriorityQueue && priority != 0 {
+		pq.AddWithOpts(priorityqueue.AddOpts{Priority: priority}, item)
+		return
+	}
+	q.Add(item)
+}
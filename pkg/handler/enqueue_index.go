@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// EnqueueRequestsFromIndex enqueues Requests for all objects of the type of list whose indexField
+// matches the object of the event.  This is used to fan out a change to one object, typically a
+// cluster-scoped one like a Node, to every object that refers to it, across all namespaces.
+//
+// The indexField must be registered with the FieldIndexer of the cache that reader reads from, and
+// must index each object by the keys of the objects it refers to.  The key of a cluster-scoped
+// object is its name, the key of a namespaced object is "<namespace>/<name>".
+//
+// E.g. to reconcile all Foos scheduled to a Node when the Node changes, index Foos by their
+// .spec.nodeName and use:
+//
+//	handler.EnqueueRequestsFromIndex(mgr.GetCache(), &FooList{}, "spec.nodeName")
+func EnqueueRequestsFromIndex(reader client.Reader, list client.ObjectList, indexField string) EventHandler {
+	return EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		key := obj.GetName()
+		if obj.GetNamespace() != "" {
+			key = obj.GetNamespace() + "/" + key
+		}
+
+		// The handler may be called concurrently, so don't share the list between calls.
+		l := list.DeepCopyObject().(client.ObjectList)
+		if err := reader.List(ctx, l, client.MatchingFields{indexField: key}); err != nil {
+			logf.FromContext(ctx).Error(err, "Could not list objects by index",
+				"list type", fmt.Sprintf("%T", list), "index", indexField, "key", key)
+			return nil
+		}
+
+		items, err := meta.ExtractList(l)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "Could not extract list items", "list type", fmt.Sprintf("%T", list))
+			return nil
+		}
+		reqs := make([]reconcile.Request, 0, len(items))
+		for _, item := range items {
+			o, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: o.GetNamespace(),
+				Name:      o.GetName(),
+			}})
+		}
+		return reqs
+	})
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package handler
 
 import (
+	"context"
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -55,29 +56,63 @@ type EnqueueRequestForOwner struct {
 
 	// mapper maps GroupVersionKinds to Resources
 	mapper meta.RESTMapper
+
+	// ignoreInjection is set if the scheme and mapper were given to NewEnqueueRequestForOwner,
+	// so that they aren't replaced by the ones injected by the Controller.
+	ignoreInjection bool
+}
+
+// OwnerOption modifies an EnqueueRequestForOwner EventHandler.
+type OwnerOption func(e *EnqueueRequestForOwner)
+
+// OnlyControllerOwner if provided will only look at the first OwnerReference with Controller: true.
+func OnlyControllerOwner() OwnerOption {
+	return func(e *EnqueueRequestForOwner) {
+		e.IsController = true
+	}
+}
+
+// NewEnqueueRequestForOwner returns an EnqueueRequestForOwner EventHandler for the given OwnerType
+// that resolves the Group and Kind of the owner with the scheme and the scope of the owner with the
+// mapper. Unlike an EnqueueRequestForOwner literal, the returned EventHandler doesn't rely on the
+// scheme and mapper being injected by the Controller, and can be used with any owner reference
+// (default) or only the controller owner reference (with OnlyControllerOwner).
+func NewEnqueueRequestForOwner(scheme *runtime.Scheme, mapper meta.RESTMapper, ownerType runtime.Object, opts ...OwnerOption) (EventHandler, error) {
+	e := &EnqueueRequestForOwner{
+		OwnerType: ownerType,
+		mapper:    mapper,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if err := e.parseOwnerTypeGroupKind(scheme); err != nil {
+		return nil, err
+	}
+	e.ignoreInjection = true
+	return e, nil
 }
 
 // Create implements EventHandler.
-func (e *EnqueueRequestForOwner) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+func (e *EnqueueRequestForOwner) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
 	reqs := map[reconcile.Request]empty{}
 	e.getOwnerReconcileRequest(evt.Object, reqs)
 	for req := range reqs {
-		q.Add(req)
+		addWithPriority(q, req, createPriority(evt))
 	}
 }
 
 // Update implements EventHandler.
-func (e *EnqueueRequestForOwner) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+func (e *EnqueueRequestForOwner) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
 	reqs := map[reconcile.Request]empty{}
 	e.getOwnerReconcileRequest(evt.ObjectOld, reqs)
 	e.getOwnerReconcileRequest(evt.ObjectNew, reqs)
 	for req := range reqs {
-		q.Add(req)
+		addWithPriority(q, req, updatePriority(evt))
 	}
 }
 
 // Delete implements EventHandler.
-func (e *EnqueueRequestForOwner) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+func (e *EnqueueRequestForOwner) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
 	reqs := map[reconcile.Request]empty{}
 	e.getOwnerReconcileRequest(evt.Object, reqs)
 	for req := range reqs {
@@ -85,8 +120,11 @@ func (e *EnqueueRequestForOwner) Delete(evt event.DeleteEvent, q workqueue.RateL
 	}
 }
 
-// Generic implements EventHandler.
-func (e *EnqueueRequestForOwner) Generic(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+// Generic implements EventHandler.  Events for external resources are ignored, as they have no owners.
+func (e *EnqueueRequestForOwner) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	if evt.External {
+		return
+	}
 	reqs := map[reconcile.Request]empty{}
 	e.getOwnerReconcileRequest(evt.Object, reqs)
 	for req := range reqs {
@@ -125,7 +163,7 @@ func (e *EnqueueRequestForOwner) getOwnerReconcileRequest(object metav1.Object,
 		if err != nil {
 			log.Error(err, "Could not parse OwnerReference APIVersion",
 				"api version", ref.APIVersion)
-			return
+			continue
 		}
 
 		// Compare the OwnerReference Group and Kind against the OwnerType Group and Kind specified by the user.
@@ -142,7 +180,7 @@ func (e *EnqueueRequestForOwner) getOwnerReconcileRequest(object metav1.Object,
 			mapping, err := e.mapper.RESTMapping(e.groupKind, refGV.Version)
 			if err != nil {
 				log.Error(err, "Could not retrieve rest mapping", "kind", e.groupKind)
-				return
+				continue
 			}
 			if mapping.Scope.Name() != meta.RESTScopeNameRoot {
 				request.Namespace = object.GetNamespace()
@@ -176,14 +214,22 @@ func (e *EnqueueRequestForOwner) getOwnersReferences(object metav1.Object) []met
 var _ inject.Scheme = &EnqueueRequestForOwner{}
 
 // InjectScheme is called by the Controller to provide a singleton scheme to the EnqueueRequestForOwner.
+// It is ignored if the EnqueueRequestForOwner was created with NewEnqueueRequestForOwner.
 func (e *EnqueueRequestForOwner) InjectScheme(s *runtime.Scheme) error {
+	if e.ignoreInjection {
+		return nil
+	}
 	return e.parseOwnerTypeGroupKind(s)
 }
 
 var _ inject.Mapper = &EnqueueRequestForOwner{}
 
 // InjectMapper  is called by the Controller to provide the rest mapper used by the manager.
+// It is ignored if the EnqueueRequestForOwner was created with NewEnqueueRequestForOwner.
 func (e *EnqueueRequestForOwner) InjectMapper(m meta.RESTMapper) error {
+	if e.ignoreInjection {
+		return nil
+	}
 	e.mapper = m
 	return nil
 }
@@ -17,7 +17,10 @@ limitations under the License.
 package handler
 
 import (
+	"context"
+
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
@@ -37,68 +40,132 @@ import (
 // of a different type - do this for events for types the Controller may be interested in, but doesn't create.
 // (e.g. If Foo responds to cluster size events, map Node events to Foo objects.)
 //
+// * Use EnqueueRequestsFromIndex to reconcile all objects that refer to the object the event is for
+// - do this to fan out events for a cluster-scoped type to objects in all namespaces.
+// (e.g. If Foos are scheduled to Nodes, map Node events to the Foos on that Node.)
+//
 // Unless you are implementing your own EventHandler, you can ignore the functions on the EventHandler interface.
 // Most users shouldn't need to implement their own EventHandler.
+//
+// The context passed to the functions of an EventHandler carries the logger of the Controller and is
+// cancelled when the Controller is stopped. EventHandlers that perform API lookups should bound
+// them with this context.
 type EventHandler interface {
 	// Create is called in response to an create event - e.g. Pod Creation.
-	Create(event.CreateEvent, workqueue.RateLimitingInterface)
+	Create(context.Context, event.CreateEvent, workqueue.RateLimitingInterface)
 
 	// Update is called in response to an update event -  e.g. Pod Updated.
-	Update(event.UpdateEvent, workqueue.RateLimitingInterface)
+	Update(context.Context, event.UpdateEvent, workqueue.RateLimitingInterface)
 
 	// Delete is called in response to a delete event - e.g. Pod Deleted.
-	Delete(event.DeleteEvent, workqueue.RateLimitingInterface)
+	Delete(context.Context, event.DeleteEvent, workqueue.RateLimitingInterface)
 
 	// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
 	// external trigger request - e.g. reconcile Autoscaling, or a Webhook.
-	Generic(event.GenericEvent, workqueue.RateLimitingInterface)
+	Generic(context.Context, event.GenericEvent, workqueue.RateLimitingInterface)
 }
 
 var _ EventHandler = Funcs{}
 
-// Funcs implements EventHandler.
+// Funcs implements EventHandler with a set of functions, one for each type of event, so that ad-hoc
+// EventHandlers can be written inline as a struct literal.  Events without a function are ignored.
 type Funcs struct {
 	// Create is called in response to an add event.  Defaults to no-op.
 	// RateLimitingInterface is used to enqueue reconcile.Requests.
-	CreateFunc func(event.CreateEvent, workqueue.RateLimitingInterface)
+	CreateFunc func(context.Context, event.CreateEvent, workqueue.RateLimitingInterface)
 
 	// Update is called in response to an update event.  Defaults to no-op.
 	// RateLimitingInterface is used to enqueue reconcile.Requests.
-	UpdateFunc func(event.UpdateEvent, workqueue.RateLimitingInterface)
+	UpdateFunc func(context.Context, event.UpdateEvent, workqueue.RateLimitingInterface)
 
 	// Delete is called in response to a delete event.  Defaults to no-op.
 	// RateLimitingInterface is used to enqueue reconcile.Requests.
-	DeleteFunc func(event.DeleteEvent, workqueue.RateLimitingInterface)
+	DeleteFunc func(context.Context, event.DeleteEvent, workqueue.RateLimitingInterface)
 
 	// GenericFunc is called in response to a generic event.  Defaults to no-op.
 	// RateLimitingInterface is used to enqueue reconcile.Requests.
-	GenericFunc func(event.GenericEvent, workqueue.RateLimitingInterface)
+	GenericFunc func(context.Context, event.GenericEvent, workqueue.RateLimitingInterface)
 }
 
 // Create implements EventHandler.
-func (h Funcs) Create(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+func (h Funcs) Create(ctx context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
 	if h.CreateFunc != nil {
-		h.CreateFunc(e, q)
+		h.CreateFunc(ctx, e, q)
 	}
 }
 
 // Delete implements EventHandler.
-func (h Funcs) Delete(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+func (h Funcs) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
 	if h.DeleteFunc != nil {
-		h.DeleteFunc(e, q)
+		h.DeleteFunc(ctx, e, q)
 	}
 }
 
 // Update implements EventHandler.
-func (h Funcs) Update(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+func (h Funcs) Update(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
 	if h.UpdateFunc != nil {
-		h.UpdateFunc(e, q)
+		h.UpdateFunc(ctx, e, q)
 	}
 }
 
 // Generic implements EventHandler.
-func (h Funcs) Generic(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+func (h Funcs) Generic(ctx context.Context, e event.GenericEvent, q workqueue.RateLimitingInterface) {
 	if h.GenericFunc != nil {
-		h.GenericFunc(e, q)
+		h.GenericFunc(ctx, e, q)
+	}
+}
+
+var _ EventHandler = TypedFuncs[client.Object]{}
+
+// TypedFuncs is a variant of Funcs whose functions receive events with objects of the concrete type T,
+// e.g. *corev1.Pod, instead of client.Object. Events with objects that are not of type T are ignored.
+type TypedFuncs[T client.Object] struct {
+	// Create is called in response to an add event.  Defaults to no-op.
+	// RateLimitingInterface is used to enqueue reconcile.Requests.
+	CreateFunc func(context.Context, event.TypedCreateEvent[T], workqueue.RateLimitingInterface)
+
+	// Update is called in response to an update event.  Defaults to no-op.
+	// RateLimitingInterface is used to enqueue reconcile.Requests.
+	UpdateFunc func(context.Context, event.TypedUpdateEvent[T], workqueue.RateLimitingInterface)
+
+	// Delete is called in response to a delete event.  Defaults to no-op.
+	// RateLimitingInterface is used to enqueue reconcile.Requests.
+	DeleteFunc func(context.Context, event.TypedDeleteEvent[T], workqueue.RateLimitingInterface)
+
+	// GenericFunc is called in response to a generic event.  Defaults to no-op.
+	// RateLimitingInterface is used to enqueue reconcile.Requests.
+	GenericFunc func(context.Context, event.TypedGenericEvent[T], workqueue.RateLimitingInterface)
+}
+
+// Create implements EventHandler.
+func (h TypedFuncs[T]) Create(ctx context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	obj, ok := e.Object.(T)
+	if h.CreateFunc != nil && ok {
+		h.CreateFunc(ctx, event.TypedCreateEvent[T]{Object: obj, IsInInitialList: e.IsInInitialList}, q)
+	}
+}
+
+// Delete implements EventHandler.
+func (h TypedFuncs[T]) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	obj, ok := e.Object.(T)
+	if h.DeleteFunc != nil && ok {
+		h.DeleteFunc(ctx, event.TypedDeleteEvent[T]{Object: obj, DeleteStateUnknown: e.DeleteStateUnknown}, q)
+	}
+}
+
+// Update implements EventHandler.
+func (h TypedFuncs[T]) Update(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	objOld, okOld := e.ObjectOld.(T)
+	objNew, okNew := e.ObjectNew.(T)
+	if h.UpdateFunc != nil && okOld && okNew {
+		h.UpdateFunc(ctx, event.TypedUpdateEvent[T]{ObjectOld: objOld, ObjectNew: objNew}, q)
+	}
+}
+
+// Generic implements EventHandler.
+func (h TypedFuncs[T]) Generic(ctx context.Context, e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	obj, ok := e.Object.(T)
+	if h.GenericFunc != nil && ok {
+		h.GenericFunc(ctx, event.TypedGenericEvent[T]{Object: obj, Metadata: e.Metadata}, q)
 	}
 }
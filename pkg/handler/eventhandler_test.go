@@ -17,6 +17,10 @@
 package handler_test
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
@@ -24,18 +28,22 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 )
 
 var _ = Describe("Eventhandler", func() {
+	ctx := context.Background()
 	var q workqueue.RateLimitingInterface
 	var instance handler.EnqueueRequestForObject
 	var pod *corev1.Pod
@@ -54,11 +62,23 @@ var _ = Describe("Eventhandler", func() {
 	})
 
 	Describe("EnqueueRequestForObject", func() {
+		It("should enqueue a Request with the Name / Namespace of an external GenericEvent.", func() {
+			evt := event.NewExternalGenericEvent("biz", "dns-record", map[string]string{"zone": "example.com"})
+			Expect(evt.External).To(BeTrue())
+			Expect(evt.Metadata).To(Equal(map[string]string{"zone": "example.com"}))
+			instance.Generic(ctx, evt, q)
+			Expect(q.Len()).To(Equal(1))
+
+			i, _ := q.Get()
+			Expect(i).To(Equal(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "biz", Name: "dns-record"}}))
+		})
+
 		It("should enqueue a Request with the Name / Namespace of the object in the CreateEvent.", func() {
 			evt := event.CreateEvent{
 				Object: pod,
 			}
-			instance.Create(evt, q)
+			instance.Create(ctx, evt, q)
 			Expect(q.Len()).To(Equal(1))
 
 			i, _ := q.Get()
@@ -72,7 +92,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.DeleteEvent{
 				Object: pod,
 			}
-			instance.Delete(evt, q)
+			instance.Delete(ctx, evt, q)
 			Expect(q.Len()).To(Equal(1))
 
 			i, _ := q.Get()
@@ -92,7 +112,7 @@ var _ = Describe("Eventhandler", func() {
 					ObjectOld: pod,
 					ObjectNew: newPod,
 				}
-				instance.Update(evt, q)
+				instance.Update(ctx, evt, q)
 				Expect(q.Len()).To(Equal(1))
 
 				i, _ := q.Get()
@@ -106,7 +126,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.GenericEvent{
 				Object: pod,
 			}
-			instance.Generic(evt, q)
+			instance.Generic(ctx, evt, q)
 			Expect(q.Len()).To(Equal(1))
 			i, _ := q.Get()
 			Expect(i).NotTo(BeNil())
@@ -120,7 +140,7 @@ var _ = Describe("Eventhandler", func() {
 				evt := event.CreateEvent{
 					Object: nil,
 				}
-				instance.Create(evt, q)
+				instance.Create(ctx, evt, q)
 				Expect(q.Len()).To(Equal(0))
 			})
 
@@ -133,7 +153,7 @@ var _ = Describe("Eventhandler", func() {
 					ObjectNew: newPod,
 					ObjectOld: nil,
 				}
-				instance.Update(evt, q)
+				instance.Update(ctx, evt, q)
 				Expect(q.Len()).To(Equal(1))
 				i, _ := q.Get()
 				Expect(i).NotTo(BeNil())
@@ -143,7 +163,7 @@ var _ = Describe("Eventhandler", func() {
 
 				evt.ObjectNew = nil
 				evt.ObjectOld = pod
-				instance.Update(evt, q)
+				instance.Update(ctx, evt, q)
 				Expect(q.Len()).To(Equal(1))
 				i, _ = q.Get()
 				Expect(i).NotTo(BeNil())
@@ -156,7 +176,7 @@ var _ = Describe("Eventhandler", func() {
 				evt := event.DeleteEvent{
 					Object: nil,
 				}
-				instance.Delete(evt, q)
+				instance.Delete(ctx, evt, q)
 				Expect(q.Len()).To(Equal(0))
 			})
 
@@ -164,7 +184,7 @@ var _ = Describe("Eventhandler", func() {
 				evt := event.GenericEvent{
 					Object: nil,
 				}
-				instance.Generic(evt, q)
+				instance.Generic(ctx, evt, q)
 				Expect(q.Len()).To(Equal(0))
 			})
 		})
@@ -173,7 +193,7 @@ var _ = Describe("Eventhandler", func() {
 	Describe("EnqueueRequestsFromMapFunc", func() {
 		It("should enqueue a Request with the function applied to the CreateEvent.", func() {
 			req := []reconcile.Request{}
-			instance := handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+			instance := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, a client.Object) []reconcile.Request {
 				defer GinkgoRecover()
 				Expect(a).To(Equal(pod))
 				req = []reconcile.Request{
@@ -190,7 +210,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.CreateEvent{
 				Object: pod,
 			}
-			instance.Create(evt, q)
+			instance.Create(ctx, evt, q)
 			Expect(q.Len()).To(Equal(2))
 
 			i1, _ := q.Get()
@@ -205,7 +225,7 @@ var _ = Describe("Eventhandler", func() {
 
 		It("should enqueue a Request with the function applied to the DeleteEvent.", func() {
 			req := []reconcile.Request{}
-			instance := handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+			instance := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, a client.Object) []reconcile.Request {
 				defer GinkgoRecover()
 				Expect(a).To(Equal(pod))
 				req = []reconcile.Request{
@@ -222,7 +242,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.DeleteEvent{
 				Object: pod,
 			}
-			instance.Delete(evt, q)
+			instance.Delete(ctx, evt, q)
 			Expect(q.Len()).To(Equal(2))
 
 			i1, _ := q.Get()
@@ -241,7 +261,7 @@ var _ = Describe("Eventhandler", func() {
 
 				req := []reconcile.Request{}
 
-				instance := handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+				instance := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, a client.Object) []reconcile.Request {
 					defer GinkgoRecover()
 					req = []reconcile.Request{
 						{
@@ -258,7 +278,7 @@ var _ = Describe("Eventhandler", func() {
 					ObjectOld: pod,
 					ObjectNew: newPod,
 				}
-				instance.Update(evt, q)
+				instance.Update(ctx, evt, q)
 				Expect(q.Len()).To(Equal(2))
 
 				i, _ := q.Get()
@@ -270,7 +290,7 @@ var _ = Describe("Eventhandler", func() {
 
 		It("should enqueue a Request with the function applied to the GenericEvent.", func() {
 			req := []reconcile.Request{}
-			instance := handler.EnqueueRequestsFromMapFunc(func(a client.Object) []reconcile.Request {
+			instance := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, a client.Object) []reconcile.Request {
 				defer GinkgoRecover()
 				Expect(a).To(Equal(pod))
 				req = []reconcile.Request{
@@ -287,7 +307,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.GenericEvent{
 				Object: pod,
 			}
-			instance.Generic(evt, q)
+			instance.Generic(ctx, evt, q)
 			Expect(q.Len()).To(Equal(2))
 
 			i1, _ := q.Get()
@@ -302,6 +322,14 @@ var _ = Describe("Eventhandler", func() {
 	})
 
 	Describe("EnqueueRequestForOwner", func() {
+		It("should not enqueue a Request for an external GenericEvent.", func() {
+			instance := handler.EnqueueRequestForOwner{
+				OwnerType: &appsv1.ReplicaSet{},
+			}
+			instance.Generic(ctx, event.NewExternalGenericEvent("biz", "dns-record", nil), q)
+			Expect(q.Len()).To(Equal(0))
+		})
+
 		It("should enqueue a Request with the Owner of the object in the CreateEvent.", func() {
 			instance := handler.EnqueueRequestForOwner{
 				OwnerType: &appsv1.ReplicaSet{},
@@ -319,7 +347,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.CreateEvent{
 				Object: pod,
 			}
-			instance.Create(evt, q)
+			instance.Create(ctx, evt, q)
 			Expect(q.Len()).To(Equal(1))
 
 			i, _ := q.Get()
@@ -344,7 +372,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.DeleteEvent{
 				Object: pod,
 			}
-			instance.Delete(evt, q)
+			instance.Delete(ctx, evt, q)
 			Expect(q.Len()).To(Equal(1))
 
 			i, _ := q.Get()
@@ -381,7 +409,7 @@ var _ = Describe("Eventhandler", func() {
 				ObjectOld: pod,
 				ObjectNew: newPod,
 			}
-			instance.Update(evt, q)
+			instance.Update(ctx, evt, q)
 			Expect(q.Len()).To(Equal(2))
 
 			i1, _ := q.Get()
@@ -422,7 +450,7 @@ var _ = Describe("Eventhandler", func() {
 				ObjectOld: pod,
 				ObjectNew: newPod,
 			}
-			instance.Update(evt, q)
+			instance.Update(ctx, evt, q)
 			Expect(q.Len()).To(Equal(1))
 
 			i, _ := q.Get()
@@ -447,7 +475,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.GenericEvent{
 				Object: pod,
 			}
-			instance.Generic(evt, q)
+			instance.Generic(ctx, evt, q)
 			Expect(q.Len()).To(Equal(1))
 
 			i, _ := q.Get()
@@ -477,7 +505,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.CreateEvent{
 				Object: pod,
 			}
-			instance.Create(evt, q)
+			instance.Create(ctx, evt, q)
 			Expect(q.Len()).To(Equal(0))
 		})
 
@@ -498,7 +526,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.CreateEvent{
 				Object: pod,
 			}
-			instance.Create(evt, q)
+			instance.Create(ctx, evt, q)
 			Expect(q.Len()).To(Equal(1))
 
 			i, _ := q.Get()
@@ -522,7 +550,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.CreateEvent{
 				Object: pod,
 			}
-			instance.Create(evt, q)
+			instance.Create(ctx, evt, q)
 			Expect(q.Len()).To(Equal(1))
 
 			i, _ := q.Get()
@@ -540,7 +568,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.CreateEvent{
 				Object: pod,
 			}
-			instance.Create(evt, q)
+			instance.Create(ctx, evt, q)
 			Expect(q.Len()).To(Equal(0))
 		})
 
@@ -585,7 +613,7 @@ var _ = Describe("Eventhandler", func() {
 				evt := event.CreateEvent{
 					Object: pod,
 				}
-				instance.Create(evt, q)
+				instance.Create(ctx, evt, q)
 				Expect(q.Len()).To(Equal(1))
 				i, _ := q.Get()
 				Expect(i).To(Equal(reconcile.Request{
@@ -619,7 +647,7 @@ var _ = Describe("Eventhandler", func() {
 				evt := event.CreateEvent{
 					Object: pod,
 				}
-				instance.Create(evt, q)
+				instance.Create(ctx, evt, q)
 				Expect(q.Len()).To(Equal(0))
 			})
 
@@ -633,7 +661,7 @@ var _ = Describe("Eventhandler", func() {
 				evt := event.CreateEvent{
 					Object: pod,
 				}
-				instance.Create(evt, q)
+				instance.Create(ctx, evt, q)
 				Expect(q.Len()).To(Equal(0))
 			})
 		})
@@ -665,7 +693,7 @@ var _ = Describe("Eventhandler", func() {
 				evt := event.CreateEvent{
 					Object: pod,
 				}
-				instance.Create(evt, q)
+				instance.Create(ctx, evt, q)
 				Expect(q.Len()).To(Equal(3))
 
 				i1, _ := q.Get()
@@ -699,7 +727,7 @@ var _ = Describe("Eventhandler", func() {
 				evt := event.CreateEvent{
 					Object: nil,
 				}
-				instance.Create(evt, q)
+				instance.Create(ctx, evt, q)
 				Expect(q.Len()).To(Equal(0))
 			})
 		})
@@ -721,7 +749,7 @@ var _ = Describe("Eventhandler", func() {
 				evt := event.CreateEvent{
 					Object: pod,
 				}
-				instance.Create(evt, q)
+				instance.Create(ctx, evt, q)
 				Expect(q.Len()).To(Equal(0))
 			})
 		})
@@ -742,7 +770,7 @@ var _ = Describe("Eventhandler", func() {
 				evt := event.CreateEvent{
 					Object: pod,
 				}
-				instance.Create(evt, q)
+				instance.Create(ctx, evt, q)
 				Expect(q.Len()).To(Equal(0))
 			})
 		})
@@ -762,11 +790,84 @@ var _ = Describe("Eventhandler", func() {
 				evt := event.CreateEvent{
 					Object: pod,
 				}
-				instance.Create(evt, q)
+				instance.Create(ctx, evt, q)
 				Expect(q.Len()).To(Equal(0))
 			})
 		})
 
+		Context("created with NewEnqueueRequestForOwner", func() {
+			It("should enqueue reconcile.Requests for all owners of the OwnerType.", func() {
+				instance, err := handler.NewEnqueueRequestForOwner(scheme.Scheme, mapper, &appsv1.ReplicaSet{})
+				Expect(err).NotTo(HaveOccurred())
+				pod.OwnerReferences = []metav1.OwnerReference{
+					{
+						Name:       "foo1-parent",
+						Kind:       "ReplicaSet",
+						APIVersion: "apps/v1",
+					},
+					{
+						Name:       "foo2-parent",
+						Kind:       "ReplicaSet",
+						APIVersion: "apps/v1",
+						Controller: &t,
+					},
+				}
+				evt := event.CreateEvent{
+					Object: pod,
+				}
+				instance.Create(ctx, evt, q)
+				Expect(q.Len()).To(Equal(2))
+			})
+
+			It("should enqueue a reconcile.Request for only the Controller owner with OnlyControllerOwner.", func() {
+				instance, err := handler.NewEnqueueRequestForOwner(scheme.Scheme, mapper, &appsv1.ReplicaSet{}, handler.OnlyControllerOwner())
+				Expect(err).NotTo(HaveOccurred())
+				pod.OwnerReferences = []metav1.OwnerReference{
+					{
+						Name:       "foo1-parent",
+						Kind:       "ReplicaSet",
+						APIVersion: "apps/v1",
+					},
+					{
+						Name:       "foo2-parent",
+						Kind:       "ReplicaSet",
+						APIVersion: "apps/v1",
+						Controller: &t,
+					},
+				}
+				evt := event.CreateEvent{
+					Object: pod,
+				}
+				instance.Create(ctx, evt, q)
+				Expect(q.Len()).To(Equal(1))
+				i, _ := q.Get()
+				Expect(i).To(Equal(reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: pod.GetNamespace(), Name: "foo2-parent"}}))
+			})
+
+			It("should keep the given scheme and mapper when the Controller injects its own.", func() {
+				instance, err := handler.NewEnqueueRequestForOwner(scheme.Scheme, mapper, &appsv1.ReplicaSet{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(inject.SchemeInto(runtime.NewScheme(), instance)).To(BeTrue())
+				Expect(inject.MapperInto(meta.NewDefaultRESTMapper(nil), instance)).To(BeTrue())
+
+				pod.OwnerReferences = []metav1.OwnerReference{
+					{
+						Name:       "foo-parent",
+						Kind:       "ReplicaSet",
+						APIVersion: "apps/v1",
+					},
+				}
+				instance.Create(ctx, event.CreateEvent{Object: pod}, q)
+				Expect(q.Len()).To(Equal(1))
+			})
+
+			It("should return an error if the OwnerType is not in the scheme.", func() {
+				_, err := handler.NewEnqueueRequestForOwner(runtime.NewScheme(), mapper, &appsv1.ReplicaSet{})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
 		Context("with an invalid APIVersion in the OwnerReference", func() {
 			It("should do nothing.", func() {
 				instance := handler.EnqueueRequestForOwner{
@@ -784,27 +885,135 @@ var _ = Describe("Eventhandler", func() {
 				evt := event.CreateEvent{
 					Object: pod,
 				}
-				instance.Create(evt, q)
+				instance.Create(ctx, evt, q)
 				Expect(q.Len()).To(Equal(0))
 			})
 		})
 	})
 
+	Describe("TypedFuncs", func() {
+		It("should call the functions with typed events for objects of the type.", func() {
+			newPod := pod.DeepCopy()
+			newPod.Name = pod.Name + "2"
+
+			var created, deleted *corev1.Pod
+			var updated event.TypedUpdateEvent[*corev1.Pod]
+			var generic event.TypedGenericEvent[*corev1.Pod]
+			instance := handler.TypedFuncs[*corev1.Pod]{
+				CreateFunc: func(_ context.Context, evt event.TypedCreateEvent[*corev1.Pod], _ workqueue.RateLimitingInterface) {
+					created = evt.Object
+				},
+				UpdateFunc: func(_ context.Context, evt event.TypedUpdateEvent[*corev1.Pod], _ workqueue.RateLimitingInterface) {
+					updated = evt
+				},
+				DeleteFunc: func(_ context.Context, evt event.TypedDeleteEvent[*corev1.Pod], _ workqueue.RateLimitingInterface) {
+					deleted = evt.Object
+				},
+				GenericFunc: func(_ context.Context, evt event.TypedGenericEvent[*corev1.Pod], _ workqueue.RateLimitingInterface) {
+					generic = evt
+				},
+			}
+
+			instance.Create(ctx, event.CreateEvent{Object: pod}, q)
+			instance.Update(ctx, event.UpdateEvent{ObjectOld: pod, ObjectNew: newPod}, q)
+			instance.Delete(ctx, event.DeleteEvent{Object: pod}, q)
+			instance.Generic(ctx, event.GenericEvent{Object: pod, Metadata: "meta"}, q)
+
+			Expect(created).To(Equal(pod))
+			Expect(updated.ObjectOld).To(Equal(pod))
+			Expect(updated.ObjectNew).To(Equal(newPod))
+			Expect(deleted).To(Equal(pod))
+			Expect(generic.Object).To(Equal(pod))
+			Expect(generic.Metadata).To(Equal("meta"))
+		})
+
+		It("should ignore events for objects of another type.", func() {
+			instance := handler.TypedFuncs[*corev1.Pod]{
+				CreateFunc: func(context.Context, event.TypedCreateEvent[*corev1.Pod], workqueue.RateLimitingInterface) {
+					defer GinkgoRecover()
+					Fail("Did not expect CreateEvent to be called.")
+				},
+				GenericFunc: func(context.Context, event.TypedGenericEvent[*corev1.Pod], workqueue.RateLimitingInterface) {
+					defer GinkgoRecover()
+					Fail("Did not expect GenericEvent to be called.")
+				},
+			}
+
+			instance.Create(ctx, event.CreateEvent{Object: &appsv1.ReplicaSet{}}, q)
+			instance.Generic(ctx, event.GenericEvent{Object: &appsv1.ReplicaSet{}}, q)
+		})
+	})
+
+	Describe("EnqueueRequestsFromTypedMapFunc", func() {
+		It("should enqueue the Requests returned by the typed function.", func() {
+			instance := handler.EnqueueRequestsFromTypedMapFunc(func(_ context.Context, p *corev1.Pod) []reconcile.Request {
+				return []reconcile.Request{
+					{NamespacedName: types.NamespacedName{Namespace: p.Namespace, Name: p.Spec.NodeName}},
+				}
+			})
+			pod.Spec.NodeName = "node1"
+
+			instance.Create(ctx, event.CreateEvent{Object: pod}, q)
+			instance.Create(ctx, event.CreateEvent{Object: &appsv1.ReplicaSet{}}, q)
+			Expect(q.Len()).To(Equal(1))
+			i, _ := q.Get()
+			Expect(i).To(Equal(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: "node1"}}))
+		})
+	})
+
+	Describe("EnqueueRequestsFromIndex", func() {
+		It("should enqueue a Request for every object matching the index.", func() {
+			reader := &indexReader{
+				index: "spec.nodeName",
+				pods: []corev1.Pod{
+					{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "pod1"}, Spec: corev1.PodSpec{NodeName: "node1"}},
+					{ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "pod2"}, Spec: corev1.PodSpec{NodeName: "node1"}},
+					{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "pod3"}, Spec: corev1.PodSpec{NodeName: "node2"}},
+				},
+			}
+			instance := handler.EnqueueRequestsFromIndex(reader, &corev1.PodList{}, "spec.nodeName")
+
+			instance.Update(ctx, event.UpdateEvent{
+				ObjectOld: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+				ObjectNew: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+			}, q)
+			Expect(q.Len()).To(Equal(2))
+
+			i1, _ := q.Get()
+			i2, _ := q.Get()
+			Expect([]interface{}{i1, i2}).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "pod1"}},
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns2", Name: "pod2"}},
+			))
+		})
+
+		It("should not enqueue anything if listing fails.", func() {
+			reader := &indexReader{index: "spec.nodeName"}
+			instance := handler.EnqueueRequestsFromIndex(reader, &corev1.PodList{}, "spec.unknown")
+
+			instance.Create(ctx, event.CreateEvent{
+				Object: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+			}, q)
+			Expect(q.Len()).To(Equal(0))
+		})
+	})
+
 	Describe("Funcs", func() {
 		failingFuncs := handler.Funcs{
-			CreateFunc: func(event.CreateEvent, workqueue.RateLimitingInterface) {
+			CreateFunc: func(context.Context, event.CreateEvent, workqueue.RateLimitingInterface) {
 				defer GinkgoRecover()
 				Fail("Did not expect CreateEvent to be called.")
 			},
-			DeleteFunc: func(event.DeleteEvent, workqueue.RateLimitingInterface) {
+			DeleteFunc: func(context.Context, event.DeleteEvent, workqueue.RateLimitingInterface) {
 				defer GinkgoRecover()
 				Fail("Did not expect DeleteEvent to be called.")
 			},
-			UpdateFunc: func(event.UpdateEvent, workqueue.RateLimitingInterface) {
+			UpdateFunc: func(context.Context, event.UpdateEvent, workqueue.RateLimitingInterface) {
 				defer GinkgoRecover()
 				Fail("Did not expect UpdateEvent to be called.")
 			},
-			GenericFunc: func(event.GenericEvent, workqueue.RateLimitingInterface) {
+			GenericFunc: func(context.Context, event.GenericEvent, workqueue.RateLimitingInterface) {
 				defer GinkgoRecover()
 				Fail("Did not expect GenericEvent to be called.")
 			},
@@ -815,12 +1024,12 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.CreateEvent{
 				Object: pod,
 			}
-			instance.CreateFunc = func(evt2 event.CreateEvent, q2 workqueue.RateLimitingInterface) {
+			instance.CreateFunc = func(_ context.Context, evt2 event.CreateEvent, q2 workqueue.RateLimitingInterface) {
 				defer GinkgoRecover()
 				Expect(q2).To(Equal(q))
 				Expect(evt2).To(Equal(evt))
 			}
-			instance.Create(evt, q)
+			instance.Create(ctx, evt, q)
 		})
 
 		It("should NOT call CreateFunc for a CreateEvent if NOT provided.", func() {
@@ -829,7 +1038,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.CreateEvent{
 				Object: pod,
 			}
-			instance.Create(evt, q)
+			instance.Create(ctx, evt, q)
 		})
 
 		It("should call UpdateFunc for an UpdateEvent if provided.", func() {
@@ -842,13 +1051,13 @@ var _ = Describe("Eventhandler", func() {
 			}
 
 			instance := failingFuncs
-			instance.UpdateFunc = func(evt2 event.UpdateEvent, q2 workqueue.RateLimitingInterface) {
+			instance.UpdateFunc = func(_ context.Context, evt2 event.UpdateEvent, q2 workqueue.RateLimitingInterface) {
 				defer GinkgoRecover()
 				Expect(q2).To(Equal(q))
 				Expect(evt2).To(Equal(evt))
 			}
 
-			instance.Update(evt, q)
+			instance.Update(ctx, evt, q)
 		})
 
 		It("should NOT call UpdateFunc for an UpdateEvent if NOT provided.", func() {
@@ -859,7 +1068,7 @@ var _ = Describe("Eventhandler", func() {
 				ObjectOld: pod,
 				ObjectNew: newPod,
 			}
-			instance.Update(evt, q)
+			instance.Update(ctx, evt, q)
 		})
 
 		It("should call DeleteFunc for a DeleteEvent if provided.", func() {
@@ -867,12 +1076,12 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.DeleteEvent{
 				Object: pod,
 			}
-			instance.DeleteFunc = func(evt2 event.DeleteEvent, q2 workqueue.RateLimitingInterface) {
+			instance.DeleteFunc = func(_ context.Context, evt2 event.DeleteEvent, q2 workqueue.RateLimitingInterface) {
 				defer GinkgoRecover()
 				Expect(q2).To(Equal(q))
 				Expect(evt2).To(Equal(evt))
 			}
-			instance.Delete(evt, q)
+			instance.Delete(ctx, evt, q)
 		})
 
 		It("should NOT call DeleteFunc for a DeleteEvent if NOT provided.", func() {
@@ -881,7 +1090,7 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.DeleteEvent{
 				Object: pod,
 			}
-			instance.Delete(evt, q)
+			instance.Delete(ctx, evt, q)
 		})
 
 		It("should call GenericFunc for a GenericEvent if provided.", func() {
@@ -889,12 +1098,12 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.GenericEvent{
 				Object: pod,
 			}
-			instance.GenericFunc = func(evt2 event.GenericEvent, q2 workqueue.RateLimitingInterface) {
+			instance.GenericFunc = func(_ context.Context, evt2 event.GenericEvent, q2 workqueue.RateLimitingInterface) {
 				defer GinkgoRecover()
 				Expect(q2).To(Equal(q))
 				Expect(evt2).To(Equal(evt))
 			}
-			instance.Generic(evt, q)
+			instance.Generic(ctx, evt, q)
 		})
 
 		It("should NOT call GenericFunc for a GenericEvent if NOT provided.", func() {
@@ -903,7 +1112,196 @@ var _ = Describe("Eventhandler", func() {
 			evt := event.GenericEvent{
 				Object: pod,
 			}
-			instance.Generic(evt, q)
+			instance.Generic(ctx, evt, q)
+		})
+	})
+
+	Describe("WithClusterName", func() {
+		It("should set the cluster name on the enqueued Requests.", func() {
+			h := handler.WithClusterName("spoke-1", &instance)
+			h.Create(ctx, event.CreateEvent{Object: pod}, q)
+			Expect(q.Len()).To(Equal(1))
+
+			i, _ := q.Get()
+			Expect(i).To(Equal(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "biz", Name: "baz"},
+				ClusterName:    "spoke-1",
+			}))
+		})
+
+		It("should not override a cluster name set by the wrapped handler.", func() {
+			h := handler.WithClusterName("spoke-1", handler.Funcs{
+				GenericFunc: func(_ context.Context, evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+					q.Add(reconcile.Request{
+						NamespacedName: types.NamespacedName{Namespace: evt.Object.GetNamespace(), Name: evt.Object.GetName()},
+						ClusterName:    "spoke-2",
+					})
+				},
+			})
+			h.Generic(ctx, event.GenericEvent{Object: pod}, q)
+
+			i, _ := q.Get()
+			Expect(i.(reconcile.Request).ClusterName).To(Equal("spoke-2"))
+		})
+
+		It("should keep the priorities of the wrapped handler on a priority queue.", func() {
+			pq := priorityqueue.New("test", workqueue.DefaultControllerRateLimiter())
+			defer pq.ShutDown()
+			other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "biz", Name: "other"}}
+
+			h := handler.WithClusterName("spoke-1", &instance)
+			h.Create(ctx, event.CreateEvent{Object: pod, IsInInitialList: true}, pq)
+			h.Create(ctx, event.CreateEvent{Object: other}, pq)
+
+			i, _ := pq.Get()
+			Expect(i).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "biz", Name: "other"}, ClusterName: "spoke-1"}))
+			i, _ = pq.Get()
+			Expect(i).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "biz", Name: "baz"}, ClusterName: "spoke-1"}))
+		})
+
+		It("should inject the logger into the wrapped handler.", func() {
+			wrapped := &loggerHandler{}
+			log := logr.Discard().WithName("controller")
+
+			injected, err := inject.LoggerInto(log, handler.WithClusterName("spoke-1", wrapped))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(injected).To(BeTrue())
+			Expect(wrapped.log).To(Equal(log))
+		})
+	})
+
+	Describe("with a priority queue", func() {
+		var pq priorityqueue.PriorityQueue
+		var other *corev1.Pod
+
+		BeforeEach(func() {
+			pq = priorityqueue.New("test", workqueue.DefaultControllerRateLimiter())
+			other = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "biz", Name: "other"},
+			}
+		})
+
+		AfterEach(func() {
+			pq.ShutDown()
+		})
+
+		It("should enqueue CreateEvents from the initial list with a low priority.", func() {
+			instance.Create(ctx, event.CreateEvent{Object: pod, IsInInitialList: true}, pq)
+			instance.Create(ctx, event.CreateEvent{Object: other}, pq)
+
+			i, _ := pq.Get()
+			Expect(i).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "biz", Name: "other"}}))
+			i, _ = pq.Get()
+			Expect(i).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "biz", Name: "baz"}}))
+		})
+
+		It("should enqueue UpdateEvents that did not change the resourceVersion with a low priority.", func() {
+			pod.ResourceVersion = "1"
+			newPod := pod.DeepCopy()
+			instance.Update(ctx, event.UpdateEvent{ObjectOld: pod, ObjectNew: newPod}, pq)
+
+			other.ResourceVersion = "1"
+			newOther := other.DeepCopy()
+			newOther.ResourceVersion = "2"
+			instance.Update(ctx, event.UpdateEvent{ObjectOld: other, ObjectNew: newOther}, pq)
+
+			i, _ := pq.Get()
+			Expect(i).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "biz", Name: "other"}}))
+			i, _ = pq.Get()
+			Expect(i).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "biz", Name: "baz"}}))
+		})
+
+		Context("with WithLowPriorityWhenUnchanged", func() {
+			var custom handler.EventHandler
+
+			BeforeEach(func() {
+				custom = handler.WithLowPriorityWhenUnchanged(handler.Funcs{
+					CreateFunc: func(_ context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+						q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: evt.Object.GetNamespace(), Name: evt.Object.GetName()}})
+					},
+					UpdateFunc: func(_ context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+						q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: evt.ObjectNew.GetNamespace(), Name: evt.ObjectNew.GetName()}})
+					},
+				})
+			})
+
+			It("should enqueue CreateEvents from the initial list with a low priority.", func() {
+				custom.Create(ctx, event.CreateEvent{Object: pod, IsInInitialList: true}, pq)
+				custom.Create(ctx, event.CreateEvent{Object: other}, pq)
+
+				i, _ := pq.Get()
+				Expect(i).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "biz", Name: "other"}}))
+				i, _ = pq.Get()
+				Expect(i).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "biz", Name: "baz"}}))
+			})
+
+			It("should enqueue UpdateEvents that did not change the resourceVersion with a low priority.", func() {
+				pod.ResourceVersion = "1"
+				newPod := pod.DeepCopy()
+				custom.Update(ctx, event.UpdateEvent{ObjectOld: pod, ObjectNew: newPod}, pq)
+
+				other.ResourceVersion = "1"
+				newOther := other.DeepCopy()
+				newOther.ResourceVersion = "2"
+				custom.Update(ctx, event.UpdateEvent{ObjectOld: other, ObjectNew: newOther}, pq)
+
+				i, _ := pq.Get()
+				Expect(i).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "biz", Name: "other"}}))
+				i, _ = pq.Get()
+				Expect(i).To(Equal(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "biz", Name: "baz"}}))
+			})
+
+			It("should not change the queue if it is not a priority queue.", func() {
+				custom.Create(ctx, event.CreateEvent{Object: pod, IsInInitialList: true}, q)
+				Expect(q.Len()).To(Equal(1))
+			})
+
+			It("should inject the logger into the wrapped handler.", func() {
+				wrapped := &loggerHandler{}
+				log := logr.Discard().WithName("controller")
+
+				injected, err := inject.LoggerInto(log, handler.WithLowPriorityWhenUnchanged(wrapped))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(injected).To(BeTrue())
+				Expect(wrapped.log).To(Equal(log))
+			})
 		})
 	})
 })
+
+// loggerHandler is an EventHandler that records the logger injected into it.
+type loggerHandler struct {
+	handler.Funcs
+	log logr.Logger
+}
+
+func (h *loggerHandler) InjectLogger(l logr.Logger) error {
+	h.log = l
+	return nil
+}
+
+// indexReader is a client.Reader that serves Pods indexed by spec.nodeName.
+type indexReader struct {
+	client.Reader
+	index string
+	pods  []corev1.Pod
+}
+
+func (r *indexReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if listOpts.FieldSelector == nil {
+		return fmt.Errorf("expected a field selector")
+	}
+	nodeName, ok := listOpts.FieldSelector.RequiresExactMatch(r.index)
+	if !ok {
+		return fmt.Errorf("index %s not found", r.index)
+	}
+	podList := list.(*corev1.PodList)
+	for _, pod := range r.pods {
+		if pod.Spec.NodeName == nodeName {
+			podList.Items = append(podList.Items, pod)
+		}
+	}
+	return nil
+}
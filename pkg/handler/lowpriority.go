@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+// WithLowPriorityWhenUnchanged wraps an EventHandler so that the requests it adds for events from the
+// initial list of a source and for resyncs, i.e. updates that didn't change the resourceVersion, are
+// added with priorityqueue.LowPriority. This keeps the latency low for requests caused by actual changes
+// while a controller starts or resyncs.
+//
+// The handlers of this package already do this, so WithLowPriorityWhenUnchanged is only needed for
+// custom EventHandlers, e.g. Funcs. It has no effect unless the controller uses a priorityqueue.PriorityQueue.
+func WithLowPriorityWhenUnchanged(h EventHandler) EventHandler {
+	return &lowPriorityWhenUnchanged{handler: h}
+}
+
+var _ EventHandler = &lowPriorityWhenUnchanged{}
+
+type lowPriorityWhenUnchanged struct {
+	handler EventHandler
+}
+
+// Create implements EventHandler.
+func (l *lowPriorityWhenUnchanged) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	l.handler.Create(ctx, evt, withPriority(q, createPriority(evt)))
+}
+
+// Update implements EventHandler.
+func (l *lowPriorityWhenUnchanged) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	l.handler.Update(ctx, evt, withPriority(q, updatePriority(evt)))
+}
+
+// Delete implements EventHandler.
+func (l *lowPriorityWhenUnchanged) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	l.handler.Delete(ctx, evt, q)
+}
+
+// Generic implements EventHandler.
+func (l *lowPriorityWhenUnchanged) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+	l.handler.Generic(ctx, evt, q)
+}
+
+// InjectFunc implements inject.Injector.
+func (l *lowPriorityWhenUnchanged) InjectFunc(f inject.Func) error {
+	if f == nil {
+		return nil
+	}
+	return f(l.handler)
+}
+
+// InjectLogger implements inject.Logger, so that the wrapped handler gets the logger of the controller.
+func (l *lowPriorityWhenUnchanged) InjectLogger(log logr.Logger) error {
+	_, err := inject.LoggerInto(log, l.handler)
+	return err
+}
+
+// withPriority returns a queue that adds items with the given priority if q is a
+// priority queue and the priority is not the default, and q otherwise.
+func withPriority(q workqueue.RateLimitingInterface, priority int) workqueue.RateLimitingInterface {
+	pq, isPriorityQueue := q.(priorityqueue.PriorityQueue)
+	if !isPriorityQueue || priority == 0 {
+		return q
+	}
+	return &priorityQueueWithPriority{PriorityQueue: pq, priority: priority}
+}
+
+// priorityQueueWithPriority is a priority queue that adds all items with a fixed priority.
+type priorityQueueWithPriority struct {
+	priorityqueue.PriorityQueue
+	priority int
+}
+
+// Add implements workqueue.Interface.
+func (q *priorityQueueWithPriority) Add(item interface{}) {
+	q.PriorityQueue.AddWithOpts(priorityqueue.AddOpts{Priority: q.priority}, item)
+}
+
+// AddWithOpts implements priorityqueue.PriorityQueue. The fixed priority is used
+// unless the given options request a lower one.
+func (q *priorityQueueWithPriority) AddWithOpts(o priorityqueue.AddOpts, items ...interface{}) {
+	if o.Priority > q.priority {
+		o.Priority = q.priority
+	}
+	q.PriorityQueue.AddWithOpts(o, items...)
+}
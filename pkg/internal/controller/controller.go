@@ -18,12 +18,15 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/internal/controller/metrics"
@@ -87,6 +90,28 @@ type Controller struct {
 
 	// RecoverPanic indicates whether the panic caused by reconcile should be recovered.
 	RecoverPanic bool
+
+	// ReconciliationTimeout is the maximum duration of a single Reconcile call.
+	// Defaults to 0, which means no timeout.
+	ReconciliationTimeout time.Duration
+
+	// LeaderElected indicates whether the controller is leader elected or always on.
+	LeaderElected *bool
+
+	// ErrorBackoff determines how long to wait before retrying a request whose reconciliation
+	// failed. If unset or if it returns false, the request is requeued rate limited.
+	ErrorBackoff func(req reconcile.Request, err error) (time.Duration, bool)
+
+	// ErrorVerbosity determines the verbosity errors returned by the Reconciler are logged with.
+	// Errors with a verbosity of 0, or all errors if unset, are logged as errors.
+	ErrorVerbosity func(err error) int
+
+	// EnableWarmup specifies whether the controller should start its sources when the manager
+	// is not the leader, see Warmup.
+	EnableWarmup *bool
+
+	// startedEventSources is true once startEventSources succeeded.
+	startedEventSources bool
 }
 
 // watchDescription contains all the information necessary to start a watch.
@@ -94,13 +119,29 @@ type watchDescription struct {
 	src        source.Source
 	handler    handler.EventHandler
 	predicates []predicate.Predicate
+
+	// started and synced record the progress of startEventSources, so that a call
+	// that failed, e.g. during warmup, can be retried without starting sources twice.
+	started bool
+	synced  bool
 }
 
 // Reconcile implements reconcile.Reconciler.
-func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (_ reconcile.Result, err error) {
+func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if c.ReconciliationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.ReconciliationTimeout)
+		defer cancel()
+	}
+	return c.reconcile(ctx, req)
+}
+
+// reconcile calls the Reconciler, without applying the ReconciliationTimeout.
+func (c *Controller) reconcile(ctx context.Context, req reconcile.Request) (_ reconcile.Result, err error) {
 	if c.RecoverPanic {
 		defer func() {
 			if r := recover(); r != nil {
+				ctrlmetrics.ReconcilePanics.WithLabelValues(c.Name).Inc()
 				for _, fn := range utilruntime.PanicHandlers {
 					fn(r)
 				}
@@ -108,25 +149,34 @@ func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (_ re
 			}
 		}()
 	}
-	log := c.Log.WithValues("name", req.Name, "namespace", req.Namespace)
-	ctx = logf.IntoContext(ctx, log)
+	if ReconcileIDFromContext(ctx) == "" {
+		ctx = c.newReconcileContext(ctx, req)
+	}
 	return c.Do.Reconcile(ctx, req)
 }
 
+// NeedLeaderElection implements the manager.LeaderElectionRunnable interface.
+func (c *Controller) NeedLeaderElection() bool {
+	if c.LeaderElected == nil {
+		return true
+	}
+	return *c.LeaderElected
+}
+
 // Watch implements controller.Controller.
 func (c *Controller) Watch(src source.Source, evthdler handler.EventHandler, prct ...predicate.Predicate) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	// Inject Cache into arguments
-	if err := c.SetFields(src); err != nil {
+	if err := c.setFields(src); err != nil {
 		return err
 	}
-	if err := c.SetFields(evthdler); err != nil {
+	if err := c.setFields(evthdler); err != nil {
 		return err
 	}
 	for _, pr := range prct {
-		if err := c.SetFields(pr); err != nil {
+		if err := c.setFields(pr); err != nil {
 			return err
 		}
 	}
@@ -134,7 +184,7 @@ func (c *Controller) Watch(src source.Source, evthdler handler.EventHandler, prc
 	// Controller hasn't started yet, store the watches locally and return.
 	//
 	// These watches are going to be held on the controller struct until the manager or user calls Start(...).
-	if !c.Started {
+	if !c.startedEventSources {
 		c.startWatches = append(c.startWatches, watchDescription{src: src, handler: evthdler, predicates: prct})
 		return nil
 	}
@@ -154,14 +204,7 @@ func (c *Controller) Start(ctx context.Context) error {
 
 	c.initMetrics()
 
-	// Set the internal context.
-	c.ctx = ctx
-
-	c.Queue = c.MakeQueue()
-	go func() {
-		<-ctx.Done()
-		c.Queue.ShutDown()
-	}()
+	c.initQueue(ctx)
 
 	wg := &sync.WaitGroup{}
 	err := func() error {
@@ -170,51 +213,13 @@ func (c *Controller) Start(ctx context.Context) error {
 		// TODO(pwittrock): Reconsider HandleCrash
 		defer utilruntime.HandleCrash()
 
-		// NB(directxman12): launch the sources *before* trying to wait for the
-		// caches to sync so that they have a chance to register their intendeded
-		// caches.
-		for _, watch := range c.startWatches {
-			c.Log.Info("Starting EventSource", "source", watch.src)
-
-			if err := watch.src.Start(ctx, watch.handler, c.Queue, watch.predicates...); err != nil {
-				return err
-			}
-		}
-
 		// Start the SharedIndexInformer factories to begin populating the SharedIndexInformer caches
 		c.Log.Info("Starting Controller")
 
-		for _, watch := range c.startWatches {
-			syncingSource, ok := watch.src.(source.SyncingSource)
-			if !ok {
-				continue
-			}
-
-			if err := func() error {
-				// use a context with timeout for launching sources and syncing caches.
-				sourceStartCtx, cancel := context.WithTimeout(ctx, c.CacheSyncTimeout)
-				defer cancel()
-
-				// WaitForSync waits for a definitive timeout, and returns if there
-				// is an error or a timeout
-				if err := syncingSource.WaitForSync(sourceStartCtx); err != nil {
-					err := fmt.Errorf("failed to wait for %s caches to sync: %w", c.Name, err)
-					c.Log.Error(err, "Could not wait for Cache to sync")
-					return err
-				}
-
-				return nil
-			}(); err != nil {
-				return err
-			}
+		if err := c.startEventSources(ctx); err != nil {
+			return err
 		}
 
-		// All the watches have been started, we can reset the local slice.
-		//
-		// We should never hold watches more than necessary, each watch source can hold a backing cache,
-		// which won't be garbage collected if we hold a reference to it.
-		c.startWatches = nil
-
 		// Launch workers to process resources
 		c.Log.Info("Starting workers", "worker count", c.MaxConcurrentReconciles)
 		wg.Add(c.MaxConcurrentReconciles)
@@ -242,6 +247,108 @@ func (c *Controller) Start(ctx context.Context) error {
 	return nil
 }
 
+// Warmup implements the manager.WarmupRunnable interface. If warmup is enabled, it
+// starts the event sources of the controller and waits for them to sync without
+// starting any workers, so that the controller can start reconciling as soon as
+// Start is called, e.g. once this replica becomes the leader.
+func (c *Controller) Warmup(ctx context.Context) error {
+	if c.EnableWarmup == nil || !*c.EnableWarmup {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.Started {
+		return nil
+	}
+	c.initQueue(ctx)
+	return c.startEventSources(ctx)
+}
+
+// initQueue sets the internal context and constructs the queue, unless that
+// already happened. Must be called with c.mu held.
+func (c *Controller) initQueue(ctx context.Context) {
+	if c.Queue != nil {
+		return
+	}
+
+	// Set the internal context. It carries the controller logger, so that
+	// event handlers of sources started by Watch can use it.
+	c.ctx = logf.IntoContext(ctx, c.Log)
+
+	c.Queue = c.MakeQueue()
+	go func() {
+		<-ctx.Done()
+		c.Queue.ShutDown()
+	}()
+}
+
+// startEventSources launches all the sources registered with this controller and waits
+// for them to sync. Once it succeeded, subsequent calls are a no-op. If it fails, e.g.
+// because a CRD isn't installed yet, the next call starts the sources that haven't been
+// started or failed to sync again. Must be called with c.mu held.
+func (c *Controller) startEventSources(ctx context.Context) error {
+	if c.startedEventSources {
+		return nil
+	}
+	// Pass the controller logger to the event handlers of the sources.
+	ctx = logf.IntoContext(ctx, c.Log)
+
+	// NB(directxman12): launch the sources *before* trying to wait for the
+	// caches to sync so that they have a chance to register their intendeded
+	// caches.
+	for i := range c.startWatches {
+		watch := &c.startWatches[i]
+		if watch.started {
+			continue
+		}
+		c.Log.Info("Starting EventSource", "source", watch.src)
+
+		if err := watch.src.Start(ctx, watch.handler, c.Queue, watch.predicates...); err != nil {
+			return err
+		}
+		watch.started = true
+	}
+
+	for i := range c.startWatches {
+		watch := &c.startWatches[i]
+		syncingSource, ok := watch.src.(source.SyncingSource)
+		if !ok || watch.synced {
+			continue
+		}
+
+		if err := func() error {
+			// use a context with timeout for launching sources and syncing caches.
+			sourceStartCtx, cancel := context.WithTimeout(ctx, c.CacheSyncTimeout)
+			defer cancel()
+
+			// WaitForSync waits for a definitive timeout, and returns if there
+			// is an error or a timeout
+			if err := syncingSource.WaitForSync(sourceStartCtx); err != nil {
+				err := fmt.Errorf("failed to wait for %s caches to sync: %w", c.Name, err)
+				c.Log.Error(err, "Could not wait for Cache to sync")
+				return err
+			}
+
+			return nil
+		}(); err != nil {
+			// The source has given up on syncing, it needs to be started again.
+			watch.started = false
+			return err
+		}
+		watch.synced = true
+	}
+
+	// All the watches have been started, we can reset the local slice.
+	//
+	// We should never hold watches more than necessary, each watch source can hold a backing cache,
+	// which won't be garbage collected if we hold a reference to it.
+	c.startWatches = nil
+	c.startedEventSources = true
+	return nil
+}
+
 // processNextWorkItem will read a single work item off the workqueue and
 // attempt to process it, by calling the reconcileHandler.
 func (c *Controller) processNextWorkItem(ctx context.Context) bool {
@@ -276,6 +383,9 @@ const (
 func (c *Controller) initMetrics() {
 	ctrlmetrics.ActiveWorkers.WithLabelValues(c.Name).Set(0)
 	ctrlmetrics.ReconcileErrors.WithLabelValues(c.Name).Add(0)
+	ctrlmetrics.ReconcileTimeouts.WithLabelValues(c.Name).Add(0)
+	ctrlmetrics.ReconcilePanics.WithLabelValues(c.Name).Add(0)
+	ctrlmetrics.TerminalReconcileErrors.WithLabelValues(c.Name).Add(0)
 	ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelError).Add(0)
 	ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelRequeueAfter).Add(0)
 	ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelRequeue).Add(0)
@@ -302,18 +412,39 @@ func (c *Controller) reconcileHandler(ctx context.Context, obj interface{}) {
 		return
 	}
 
-	log := c.Log.WithValues("name", req.Name, "namespace", req.Namespace)
-	ctx = logf.IntoContext(ctx, log)
+	ctx = c.newReconcileContext(ctx, req)
+	log := logf.FromContext(ctx)
+
+	// Apply the ReconciliationTimeout here rather than in Reconcile, so that a timeout can be
+	// told apart from the Reconciler returning a deadline error of another context.
+	reconcileCtx := ctx
+	if c.ReconciliationTimeout > 0 {
+		var cancel context.CancelFunc
+		reconcileCtx, cancel = context.WithTimeout(ctx, c.ReconciliationTimeout)
+		defer cancel()
+	}
 
 	// RunInformersAndControllers the syncHandler, passing it the Namespace/Name string of the
 	// resource to be synced.
-	result, err := c.Reconcile(ctx, req)
+	result, err := c.reconcile(reconcileCtx, req)
 	switch {
 	case err != nil:
-		c.Queue.AddRateLimited(req)
+		if errors.Is(err, reconcile.TerminalError(nil)) {
+			// Terminal errors are not retried, so we Forget the item
+			// to reset its rate limiting backoff.
+			c.Queue.Forget(obj)
+			ctrlmetrics.TerminalReconcileErrors.WithLabelValues(c.Name).Inc()
+		} else {
+			c.requeueAfterError(req, err)
+		}
 		ctrlmetrics.ReconcileErrors.WithLabelValues(c.Name).Inc()
 		ctrlmetrics.ReconcileTotal.WithLabelValues(c.Name, labelError).Inc()
-		log.Error(err, "Reconciler error")
+		if reconcileCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+			ctrlmetrics.ReconcileTimeouts.WithLabelValues(c.Name).Inc()
+			log.Error(err, "Reconciler timed out", "timeout", c.ReconciliationTimeout)
+			return
+		}
+		c.logReconcileError(log, err)
 	case result.RequeueAfter > 0:
 		// The result.RequeueAfter request will be lost, if it is returned
 		// along with a non-nil error. But this is intended as
@@ -333,6 +464,75 @@ func (c *Controller) reconcileHandler(ctx context.Context, obj interface{}) {
 	}
 }
 
+// newReconcileContext returns a context carrying a new reconcile ID and a
+// logger populated with the request and that ID, so all logs of a single
+// reconciliation can be correlated.
+func (c *Controller) newReconcileContext(ctx context.Context, req reconcile.Request) context.Context {
+	reconcileID := uuid.NewUUID()
+	log := c.Log.WithValues("name", req.Name, "namespace", req.Namespace, "reconcileID", reconcileID)
+	if req.ClusterName != "" {
+		log = log.WithValues("cluster", req.ClusterName)
+	}
+	ctx = logf.IntoContext(ctx, log)
+	return context.WithValue(ctx, reconcileIDKey{}, reconcileID)
+}
+
+// reconcileIDKey is the context key the reconcile ID is stored under.
+type reconcileIDKey struct{}
+
+// ReconcileIDFromContext gets the reconcileID from the current context.
+// It returns an empty UID if the context wasn't created for a reconciliation.
+func ReconcileIDFromContext(ctx context.Context) types.UID {
+	r, ok := ctx.Value(reconcileIDKey{}).(types.UID)
+	if !ok {
+		return ""
+	}
+	return r
+}
+
+// logReconcileError logs an error returned by the Reconciler.  Errors that
+// ErrorVerbosity classifies as expected are logged as info messages at the
+// returned verbosity instead.
+func (c *Controller) logReconcileError(log logr.Logger, err error) {
+	if c.ErrorVerbosity != nil {
+		if level := c.ErrorVerbosity(err); level > 0 {
+			log.V(level).Info("Reconciler error", "error", err)
+			return
+		}
+	}
+	log.Error(err, "Reconciler error")
+}
+
+// requeueAfterError requeues the request after its reconciliation failed with the given error.
+// The delay requested by a reconcile.RetryAfterError takes precedence over ErrorBackoff, which
+// in turn takes precedence over the rate limiter.  A RetryAfterError without a positive delay
+// is handled like a plain error, so that the Request isn't retried in a hot loop.
+func (c *Controller) requeueAfterError(req reconcile.Request, err error) {
+	if after, ok := reconcile.RetryAfter(err); ok && after > 0 {
+		c.Queue.AddAfter(req, after)
+		return
+	}
+	if c.ErrorBackoff != nil {
+		if after, ok := c.ErrorBackoff(req, err); ok {
+			c.Queue.AddAfter(req, after)
+			return
+		}
+	}
+	c.Queue.AddRateLimited(req)
+}
+
+// setFields injects the dependencies of the manager into i, as well as the
+// logger of this controller, so that it is preferred over the manager's one.
+func (c *Controller) setFields(i interface{}) error {
+	if err := c.SetFields(i); err != nil {
+		return err
+	}
+	if _, err := inject.LoggerInto(c.Log, i); err != nil {
+		return err
+	}
+	return nil
+}
+
 // GetLogger returns this controller's logger.
 func (c *Controller) GetLogger() logr.Logger {
 	return c.Log
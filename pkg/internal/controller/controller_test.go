@@ -17,16 +17,19 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap/zapcore"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,6 +43,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/internal/controller/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/internal/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
@@ -70,7 +74,7 @@ var _ = Describe("controller", func() {
 			MaxConcurrentReconciles: 1,
 			Do:                      fakeReconcile,
 			MakeQueue:               func() workqueue.RateLimitingInterface { return queue },
-			Log:                     log.RuntimeLog.WithName("controller").WithName("test"),
+			Log:                     log.RuntimeLog.WithValues("controller", "test"),
 		}
 		Expect(ctrl.InjectFunc(func(interface{}) error { return nil })).To(Succeed())
 	})
@@ -121,6 +125,111 @@ var _ = Describe("controller", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("[recovered]"))
 		})
+
+		It("should count recovered panics", func() {
+			ctrlmetrics.ReconcilePanics.Reset()
+			ctrl.RecoverPanic = true
+			ctrl.Do = reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				panic("reconcile failed")
+			})
+			_, err := ctrl.Reconcile(context.Background(),
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "foo", Name: "bar"}})
+			Expect(err).To(HaveOccurred())
+
+			var reconcilePanics dto.Metric
+			Expect(ctrlmetrics.ReconcilePanics.WithLabelValues(ctrl.Name).Write(&reconcilePanics)).To(Succeed())
+			Expect(reconcilePanics.GetCounter().GetValue()).To(Equal(1.0))
+		})
+
+		It("should pass a unique reconcileID to every reconciliation", func() {
+			var ids []types.UID
+			ctrl.Do = reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				ids = append(ids, ReconcileIDFromContext(ctx))
+				return reconcile.Result{}, nil
+			})
+			for i := 0; i < 2; i++ {
+				_, err := ctrl.Reconcile(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(ids).To(HaveLen(2))
+			Expect(ids[0]).NotTo(BeEmpty())
+			Expect(ids[1]).NotTo(BeEmpty())
+			Expect(ids[0]).NotTo(Equal(ids[1]))
+		})
+
+		It("should keep the reconcileID of the reconcile handler", func() {
+			ctx := ctrl.newReconcileContext(context.Background(), request)
+			ctrl.Do = reconcile.Func(func(reconcileCtx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				Expect(ReconcileIDFromContext(reconcileCtx)).To(Equal(ReconcileIDFromContext(ctx)))
+				return reconcile.Result{}, nil
+			})
+			_, err := ctrl.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should return an empty reconcileID outside of reconciliations", func() {
+			Expect(ReconcileIDFromContext(context.Background())).To(BeEmpty())
+		})
+
+		It("should log errors at the verbosity determined by ErrorVerbosity", func() {
+			logOut := &bytes.Buffer{}
+			ctrl.Log = zap.New(zap.WriteTo(logOut), zap.Level(zapcore.Level(-2)))
+			expected := errors.New("expected error")
+			ctrl.ErrorVerbosity = func(err error) int {
+				if errors.Is(err, expected) {
+					return 2
+				}
+				return 0
+			}
+
+			ctrl.logReconcileError(ctrl.Log, expected)
+			Expect(logOut.String()).To(ContainSubstring("Reconciler error"))
+			Expect(logOut.String()).NotTo(ContainSubstring(`"level":"error"`))
+
+			logOut.Reset()
+			ctrl.logReconcileError(ctrl.Log, errors.New("unexpected error"))
+			Expect(logOut.String()).To(ContainSubstring("Reconciler error"))
+			Expect(logOut.String()).To(ContainSubstring(`"level":"error"`))
+		})
+
+		It("should cancel the context passed to the Reconciler once ReconciliationTimeout expires", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ctrl.ReconciliationTimeout = 10 * time.Millisecond
+			ctrl.Do = reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				<-ctx.Done()
+				return reconcile.Result{}, ctx.Err()
+			})
+			_, err := ctrl.Reconcile(ctx,
+				reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "foo", Name: "bar"}})
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+		})
+
+		It("should only count reconciles that ran into the ReconciliationTimeout as timeouts", func() {
+			var timeouts dto.Metric
+			ctrlmetrics.ReconcileTimeouts.Reset()
+			ctrl.Queue = queue
+
+			By("Returning a deadline error of another context")
+			ctrl.ReconciliationTimeout = time.Hour
+			ctrl.Do = reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, fmt.Errorf("calling the cloud API: %w", context.DeadlineExceeded)
+			})
+			ctrl.reconcileHandler(context.Background(), request)
+			Expect(ctrlmetrics.ReconcileTimeouts.WithLabelValues(ctrl.Name).Write(&timeouts)).To(Succeed())
+			Expect(timeouts.GetCounter().GetValue()).To(BeZero())
+
+			By("Running into the ReconciliationTimeout")
+			ctrl.ReconciliationTimeout = 10 * time.Millisecond
+			ctrl.Do = reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				<-ctx.Done()
+				return reconcile.Result{}, ctx.Err()
+			})
+			ctrl.reconcileHandler(context.Background(), request)
+			Expect(ctrlmetrics.ReconcileTimeouts.WithLabelValues(ctrl.Name).Write(&timeouts)).To(Succeed())
+			Expect(timeouts.GetCounter().GetValue()).To(Equal(1.0))
+		})
 	})
 
 	Describe("Start", func() {
@@ -210,7 +319,7 @@ var _ = Describe("controller", func() {
 			ctrl.startWatches = []watchDescription{{
 				src: ins,
 				handler: handler.Funcs{
-					GenericFunc: func(evt event.GenericEvent, q workqueue.RateLimitingInterface) {
+					GenericFunc: func(_ context.Context, evt event.GenericEvent, q workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						close(processed)
 					},
@@ -306,6 +415,113 @@ var _ = Describe("controller", func() {
 
 	})
 
+	Describe("Warmup", func() {
+		It("should not start the sources if warmup is not enabled", func() {
+			started := 0
+			ctrl.startWatches = []watchDescription{{
+				src: source.Func(func(context.Context, handler.EventHandler, workqueue.RateLimitingInterface, ...predicate.Predicate) error {
+					started++
+					return nil
+				}),
+			}}
+
+			Expect(ctrl.Warmup(context.Background())).To(Succeed())
+			Expect(started).To(Equal(0))
+		})
+
+		It("should start the sources only once if warmup is enabled", func() {
+			t := true
+			ctrl.EnableWarmup = &t
+			started := 0
+			ctrl.startWatches = []watchDescription{{
+				src: source.Func(func(context.Context, handler.EventHandler, workqueue.RateLimitingInterface, ...predicate.Predicate) error {
+					started++
+					return nil
+				}),
+			}}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			Expect(ctrl.Warmup(ctx)).To(Succeed())
+			Expect(started).To(Equal(1))
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(ctrl.Start(ctx)).To(Succeed())
+			}()
+			Eventually(func() bool {
+				ctrl.mu.Lock()
+				defer ctrl.mu.Unlock()
+				return ctrl.Started
+			}).Should(BeTrue())
+			Expect(started).To(Equal(1))
+		})
+
+		It("should retry starting the sources from Start if warmup failed", func() {
+			t := true
+			ctrl.EnableWarmup = &t
+			started := 0
+			ctrl.startWatches = []watchDescription{{
+				src: source.Func(func(context.Context, handler.EventHandler, workqueue.RateLimitingInterface, ...predicate.Predicate) error {
+					started++
+					if started == 1 {
+						return fmt.Errorf("expected error")
+					}
+					return nil
+				}),
+			}}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			Expect(ctrl.Warmup(ctx)).To(MatchError("expected error"))
+
+			go func() {
+				defer GinkgoRecover()
+				Expect(ctrl.Start(ctx)).To(Succeed())
+			}()
+			Eventually(func() bool {
+				ctrl.mu.Lock()
+				defer ctrl.mu.Unlock()
+				return ctrl.Started
+			}).Should(BeTrue())
+			Expect(started).To(Equal(2))
+		})
+
+		It("should restart only the sources that failed to sync if warmup failed", func() {
+			t := true
+			ctrl.EnableWarmup = &t
+			other := 0
+			syncing := &flakySyncingSource{}
+			ctrl.startWatches = []watchDescription{
+				{src: source.Func(func(context.Context, handler.EventHandler, workqueue.RateLimitingInterface, ...predicate.Predicate) error {
+					other++
+					return nil
+				})},
+				{src: syncing},
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			Expect(ctrl.Warmup(ctx)).To(MatchError(ContainSubstring("expected sync error")))
+			Expect(ctrl.Warmup(ctx)).To(Succeed())
+			Expect(other).To(Equal(1))
+			Expect(syncing.started).To(Equal(2))
+			Expect(ctrl.startWatches).To(BeEmpty())
+		})
+	})
+
+	Describe("NeedLeaderElection", func() {
+		It("should return true if LeaderElected is nil", func() {
+			Expect(ctrl.NeedLeaderElection()).To(BeTrue())
+		})
+
+		It("should return the value of LeaderElected if set", func() {
+			f := false
+			ctrl.LeaderElected = &f
+			Expect(ctrl.NeedLeaderElection()).To(BeFalse())
+		})
+	})
+
 	Describe("Watch", func() {
 		It("should inject dependencies into the Source", func() {
 			src := &source.Kind{Type: &corev1.Pod{}}
@@ -368,6 +584,19 @@ var _ = Describe("controller", func() {
 			Expect(ctrl.Watch(src, evthdl)).To(Equal(expected))
 		})
 
+		It("should inject the controller's logger, overriding the one of SetFields", func() {
+			src := &loggerInjectable{Kind: source.Kind{Type: &corev1.Pod{}}}
+			Expect(src.InjectCache(informers)).To(Succeed())
+			evthdl := &handler.EnqueueRequestForObject{}
+			ctrl.SetFields = func(i interface{}) error {
+				_, err := inject.LoggerInto(logr.Discard(), i)
+				return err
+			}
+			ctrl.Log = log.RuntimeLog.WithName("injected")
+			Expect(ctrl.Watch(src, evthdl)).NotTo(HaveOccurred())
+			Expect(src.log).To(Equal(ctrl.Log))
+		})
+
 		PIt("should inject dependencies into the Reconciler", func() {
 			// TODO(community): Write this
 		})
@@ -533,6 +762,127 @@ var _ = Describe("controller", func() {
 			Eventually(func() int { return dq.NumRequeues(request) }).Should(Equal(0))
 		})
 
+		It("should not requeue a Request if there is a terminal error", func() {
+			dq := &DelegatingQueue{RateLimitingInterface: ctrl.MakeQueue()}
+			ctrl.MakeQueue = func() workqueue.RateLimitingInterface { return dq }
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(ctrl.Start(ctx)).NotTo(HaveOccurred())
+			}()
+
+			dq.Add(request)
+			Expect(dq.getCounts()).To(Equal(countInfo{Trying: 1}))
+
+			By("Invoking Reconciler which returns a terminal error")
+			fakeReconcile.AddResult(reconcile.Result{}, reconcile.TerminalError(fmt.Errorf("invalid spec")))
+			Expect(<-reconciled).To(Equal(request))
+			Eventually(dq.getCounts).Should(Equal(countInfo{Trying: 0}))
+
+			By("Removing the item from the queue")
+			Eventually(dq.Len).Should(Equal(0))
+			Eventually(func() int { return dq.NumRequeues(request) }).Should(Equal(0))
+		})
+
+		It("should requeue a Request after the duration of a RetryAfterError", func() {
+			dq := &DelegatingQueue{RateLimitingInterface: ctrl.MakeQueue()}
+			ctrl.MakeQueue = func() workqueue.RateLimitingInterface { return dq }
+			ctrl.ErrorBackoff = func(req reconcile.Request, err error) (time.Duration, bool) {
+				defer GinkgoRecover()
+				Fail("ErrorBackoff should not be called for a RetryAfterError")
+				return 0, false
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(ctrl.Start(ctx)).NotTo(HaveOccurred())
+			}()
+
+			dq.Add(request)
+			Expect(dq.getCounts()).To(Equal(countInfo{Trying: 1}))
+
+			By("Invoking Reconciler which returns a RetryAfterError")
+			fakeReconcile.AddResult(reconcile.Result{}, reconcile.RetryAfterError(fmt.Errorf("not ready"), time.Millisecond))
+			Expect(<-reconciled).To(Equal(request))
+			Eventually(dq.getCounts).Should(Equal(countInfo{Trying: 1, AddAfter: 1}))
+
+			By("Invoking Reconciler a second time without error")
+			fakeReconcile.AddResult(reconcile.Result{}, nil)
+			Expect(<-reconciled).To(Equal(request))
+			Eventually(dq.getCounts).Should(Equal(countInfo{Trying: 0, AddAfter: 1}))
+		})
+
+		It("should requeue a Request with the ErrorBackoff or the rate limiter if a RetryAfterError has no positive duration", func() {
+			dq := &DelegatingQueue{RateLimitingInterface: ctrl.MakeQueue()}
+			ctrl.MakeQueue = func() workqueue.RateLimitingInterface { return dq }
+			errNotReady := fmt.Errorf("not ready")
+			ctrl.ErrorBackoff = func(req reconcile.Request, err error) (time.Duration, bool) {
+				return time.Millisecond, errors.Is(err, errNotReady)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(ctrl.Start(ctx)).NotTo(HaveOccurred())
+			}()
+
+			dq.Add(request)
+			Expect(dq.getCounts()).To(Equal(countInfo{Trying: 1}))
+
+			By("Invoking Reconciler which returns a RetryAfterError without a duration")
+			fakeReconcile.AddResult(reconcile.Result{}, reconcile.RetryAfterError(errNotReady, 0))
+			Expect(<-reconciled).To(Equal(request))
+			Eventually(dq.getCounts).Should(Equal(countInfo{Trying: 1, AddAfter: 1}))
+
+			By("Invoking Reconciler which returns a RetryAfterError with a negative duration not handled by ErrorBackoff")
+			fakeReconcile.AddResult(reconcile.Result{}, reconcile.RetryAfterError(fmt.Errorf("still not ready"), -time.Second))
+			Expect(<-reconciled).To(Equal(request))
+			Eventually(dq.getCounts).Should(Equal(countInfo{Trying: 1, AddAfter: 1, AddRateLimited: 1}))
+
+			By("Invoking Reconciler a third time without error")
+			fakeReconcile.AddResult(reconcile.Result{}, nil)
+			Expect(<-reconciled).To(Equal(request))
+			Eventually(dq.getCounts).Should(Equal(countInfo{Trying: 0, AddAfter: 1, AddRateLimited: 1}))
+		})
+
+		It("should requeue a Request after the duration returned by ErrorBackoff if there is an error", func() {
+			dq := &DelegatingQueue{RateLimitingInterface: ctrl.MakeQueue()}
+			ctrl.MakeQueue = func() workqueue.RateLimitingInterface { return dq }
+			ctrl.ErrorBackoff = func(req reconcile.Request, err error) (time.Duration, bool) {
+				return time.Millisecond, err.Error() == "retry quickly"
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(ctrl.Start(ctx)).NotTo(HaveOccurred())
+			}()
+
+			dq.Add(request)
+			Expect(dq.getCounts()).To(Equal(countInfo{Trying: 1}))
+
+			By("Invoking Reconciler which returns an error handled by ErrorBackoff")
+			fakeReconcile.AddResult(reconcile.Result{}, fmt.Errorf("retry quickly"))
+			Expect(<-reconciled).To(Equal(request))
+			Eventually(dq.getCounts).Should(Equal(countInfo{Trying: 1, AddAfter: 1}))
+
+			By("Invoking Reconciler which returns an error not handled by ErrorBackoff")
+			fakeReconcile.AddResult(reconcile.Result{}, fmt.Errorf("something's wrong"))
+			Expect(<-reconciled).To(Equal(request))
+			Eventually(dq.getCounts).Should(Equal(countInfo{Trying: 1, AddAfter: 1, AddRateLimited: 1}))
+
+			By("Invoking Reconciler a third time, where it finally does not return an error")
+			fakeReconcile.AddResult(reconcile.Result{}, nil)
+			Expect(<-reconciled).To(Equal(request))
+			Eventually(dq.getCounts).Should(Equal(countInfo{Trying: 0, AddAfter: 1, AddRateLimited: 1}))
+		})
+
 		It("should requeue a Request with rate limiting if the Result sets Requeue:true and continue processing items", func() {
 			dq := &DelegatingQueue{RateLimitingInterface: ctrl.MakeQueue()}
 			ctrl.MakeQueue = func() workqueue.RateLimitingInterface { return dq }
@@ -800,6 +1150,49 @@ var _ = Describe("controller", func() {
 				Eventually(func() int { return queue.NumRequeues(request) }).Should(Equal(0))
 			}, 2.0)
 
+			It("should report the configured max concurrent reconciles", func() {
+				var workerCount dto.Metric
+				ctrlmetrics.WorkerCount.Reset()
+				ctrl.MaxConcurrentReconciles = 3
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				go func() {
+					defer GinkgoRecover()
+					Expect(ctrl.Start(ctx)).NotTo(HaveOccurred())
+				}()
+
+				Eventually(func() float64 {
+					Expect(ctrlmetrics.WorkerCount.WithLabelValues(ctrl.Name).Write(&workerCount)).To(Succeed())
+					return workerCount.GetGauge().GetValue()
+				}, 2.0).Should(Equal(3.0))
+			}, 2.0)
+
+			It("should track the number of active workers", func() {
+				var activeWorkers dto.Metric
+				ctrlmetrics.ActiveWorkers.Reset()
+				getActiveWorkers := func() float64 {
+					Expect(ctrlmetrics.ActiveWorkers.WithLabelValues(ctrl.Name).Write(&activeWorkers)).To(Succeed())
+					return activeWorkers.GetGauge().GetValue()
+				}
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				go func() {
+					defer GinkgoRecover()
+					Expect(ctrl.Start(ctx)).NotTo(HaveOccurred())
+				}()
+				queue.Add(request)
+
+				By("Blocking the Reconciler until a result is added")
+				Eventually(getActiveWorkers, 2.0).Should(Equal(1.0))
+
+				By("Letting the Reconciler finish")
+				fakeReconcile.AddResult(reconcile.Result{}, nil)
+				Expect(<-reconciled).To(Equal(request))
+				Eventually(getActiveWorkers, 2.0).Should(Equal(0.0))
+			}, 4.0)
+
 			It("should add a reconcile time to the reconcile time histogram", func() {
 				var reconcileTime dto.Metric
 				ctrlmetrics.ReconcileTime.Reset()
@@ -922,6 +1315,16 @@ func (f *fakeReconciler) Reconcile(_ context.Context, r reconcile.Request) (reco
 	return res.Result, res.Err
 }
 
+type loggerInjectable struct {
+	source.Kind
+	log logr.Logger
+}
+
+func (l *loggerInjectable) InjectLogger(log logr.Logger) error {
+	l.log = log
+	return nil
+}
+
 type singnallingSourceWrapper struct {
 	cacheSyncDone chan struct{}
 	source.SyncingSource
@@ -950,3 +1353,20 @@ func (c *cacheWithIndefinitelyBlockingGetInformer) GetInformer(ctx context.Conte
 	<-ctx.Done()
 	return nil, errors.New("GetInformer timed out")
 }
+
+// flakySyncingSource is a SyncingSource that fails to sync after its first start.
+type flakySyncingSource struct {
+	started int
+}
+
+func (f *flakySyncingSource) Start(context.Context, handler.EventHandler, workqueue.RateLimitingInterface, ...predicate.Predicate) error {
+	f.started++
+	return nil
+}
+
+func (f *flakySyncingSource) WaitForSync(context.Context) error {
+	if f.started == 1 {
+		return fmt.Errorf("expected sync error")
+	}
+	return nil
+}
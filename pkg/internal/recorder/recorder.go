@@ -36,7 +36,7 @@ import (
 type EventBroadcasterProducer func() (caster record.EventBroadcaster, stopWithProvider bool)
 
 // Provider is a recorder.Provider that records events to the k8s API server
-// and to a logr Logger.
+// (or to a custom sink) and to a logr Logger.
 type Provider struct {
 	lock    sync.RWMutex
 	stopped bool
@@ -45,7 +45,7 @@ type Provider struct {
 	scheme *runtime.Scheme
 	// logger is the logger to use when logging diagnostic event info
 	logger          logr.Logger
-	evtClient       typedcorev1.EventInterface
+	sink            record.EventSink
 	makeBroadcaster EventBroadcasterProducer
 
 	broadcasterOnce sync.Once
@@ -67,17 +67,20 @@ func (p *Provider) Stop(shutdownCtx context.Context) {
 	doneCh := make(chan struct{})
 
 	go func() {
-		// technically, this could start the broadcaster, but practically, it's
-		// almost certainly already been started (e.g. by leader election).  We
-		// need to invoke this to ensure that we don't inadvertently race with
-		// an invocation of getBroadcaster.
-		broadcaster := p.getBroadcaster()
-		if p.stopBroadcaster {
-			p.lock.Lock()
-			broadcaster.Shutdown()
+		// Prevent a broadcaster from being started from here on if none has been
+		// yet: nothing has been emitted, so there is nothing to flush, and starting
+		// one just to shut it down again would leak its goroutines.  We need to go
+		// through the once to ensure that we don't race with getBroadcaster.
+		p.broadcasterOnce.Do(func() {})
+		p.lock.Lock()
+		switch {
+		case p.broadcaster == nil:
+			p.stopped = true
+		case p.stopBroadcaster:
+			p.broadcaster.Shutdown()
 			p.stopped = true
-			p.lock.Unlock()
 		}
+		p.lock.Unlock()
 		close(doneCh)
 	}()
 
@@ -88,7 +91,8 @@ func (p *Provider) Stop(shutdownCtx context.Context) {
 }
 
 // getBroadcaster ensures that a broadcaster is started for this
-// provider, and returns it.  It's threadsafe.
+// provider, and returns it.  It returns nil if the provider was
+// stopped before any broadcaster was started.  It's threadsafe.
 func (p *Provider) getBroadcaster() record.EventBroadcaster {
 	// NB(directxman12): this can technically still leak if something calls
 	// "getBroadcaster" (i.e. Emits an Event) but never calls Start, but if we
@@ -98,7 +102,7 @@ func (p *Provider) getBroadcaster() record.EventBroadcaster {
 
 	p.broadcasterOnce.Do(func() {
 		broadcaster, stop := p.makeBroadcaster()
-		broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: p.evtClient})
+		broadcaster.StartRecordingToSink(p.sink)
 		broadcaster.StartEventWatcher(
 			func(e *corev1.Event) {
 				p.logger.V(1).Info(e.Type, "object", e.InvolvedObject, "reason", e.Reason, "message", e.Message)
@@ -110,14 +114,19 @@ func (p *Provider) getBroadcaster() record.EventBroadcaster {
 	return p.broadcaster
 }
 
-// NewProvider create a new Provider instance.
-func NewProvider(config *rest.Config, scheme *runtime.Scheme, logger logr.Logger, makeBroadcaster EventBroadcasterProducer) (*Provider, error) {
-	clientSet, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to init clientSet: %w", err)
+// NewProvider create a new Provider instance.  Events are recorded to the
+// given sink, or to the core v1 Events API of the cluster described by
+// config if sink is nil.
+func NewProvider(config *rest.Config, scheme *runtime.Scheme, logger logr.Logger, makeBroadcaster EventBroadcasterProducer, sink record.EventSink) (*Provider, error) {
+	if sink == nil {
+		clientSet, err := kubernetes.NewForConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init clientSet: %w", err)
+		}
+		sink = &typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")}
 	}
 
-	p := &Provider{scheme: scheme, logger: logger, makeBroadcaster: makeBroadcaster, evtClient: clientSet.CoreV1().Events("")}
+	p := &Provider{scheme: scheme, logger: logger, makeBroadcaster: makeBroadcaster, sink: sink}
 	return p, nil
 }
 
@@ -144,6 +153,10 @@ type lazyRecorder struct {
 func (l *lazyRecorder) ensureRecording() {
 	l.recOnce.Do(func() {
 		broadcaster := l.prov.getBroadcaster()
+		if broadcaster == nil {
+			// The provider has been stopped already.
+			return
+		}
 		l.rec = broadcaster.NewRecorder(l.prov.scheme, corev1.EventSource{Component: l.name})
 	})
 }
@@ -152,7 +165,7 @@ func (l *lazyRecorder) Event(object runtime.Object, eventtype, reason, message s
 	l.ensureRecording()
 
 	l.prov.lock.RLock()
-	if !l.prov.stopped {
+	if !l.prov.stopped && l.rec != nil {
 		l.rec.Event(object, eventtype, reason, message)
 	}
 	l.prov.lock.RUnlock()
@@ -161,7 +174,7 @@ func (l *lazyRecorder) Eventf(object runtime.Object, eventtype, reason, messageF
 	l.ensureRecording()
 
 	l.prov.lock.RLock()
-	if !l.prov.stopped {
+	if !l.prov.stopped && l.rec != nil {
 		l.rec.Eventf(object, eventtype, reason, messageFmt, args...)
 	}
 	l.prov.lock.RUnlock()
@@ -170,7 +183,7 @@ func (l *lazyRecorder) AnnotatedEventf(object runtime.Object, annotations map[st
 	l.ensureRecording()
 
 	l.prov.lock.RLock()
-	if !l.prov.stopped {
+	if !l.prov.stopped && l.rec != nil {
 		l.rec.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
 	}
 	l.prov.lock.RUnlock()
@@ -17,9 +17,13 @@ limitations under the License.
 package recorder_test
 
 import (
+	"context"
+	"sync"
+
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/internal/recorder"
@@ -29,7 +33,7 @@ var _ = Describe("recorder.Provider", func() {
 	makeBroadcaster := func() (record.EventBroadcaster, bool) { return record.NewBroadcaster(), true }
 	Describe("NewProvider", func() {
 		It("should return a provider instance and a nil error.", func() {
-			provider, err := recorder.NewProvider(cfg, scheme.Scheme, logr.DiscardLogger{}, makeBroadcaster)
+			provider, err := recorder.NewProvider(cfg, scheme.Scheme, logr.DiscardLogger{}, makeBroadcaster, nil)
 			Expect(provider).NotTo(BeNil())
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -38,14 +42,42 @@ var _ = Describe("recorder.Provider", func() {
 			// Invalid the config
 			cfg1 := *cfg
 			cfg1.Host = "invalid host"
-			_, err := recorder.NewProvider(&cfg1, scheme.Scheme, logr.DiscardLogger{}, makeBroadcaster)
+			_, err := recorder.NewProvider(&cfg1, scheme.Scheme, logr.DiscardLogger{}, makeBroadcaster, nil)
 			Expect(err).NotTo(BeNil())
 			Expect(err.Error()).To(ContainSubstring("failed to init clientSet"))
 		})
+
+		It("should record events to the given sink instead of the API server.", func() {
+			sink := &fakeSink{}
+			provider, err := recorder.NewProvider(nil, scheme.Scheme, logr.DiscardLogger{}, makeBroadcaster, sink)
+			Expect(err).NotTo(HaveOccurred())
+			defer provider.Stop(context.Background())
+
+			provider.GetEventRecorderFor("test").Event(&corev1.Pod{}, corev1.EventTypeNormal, "test-reason", "test-message")
+			Eventually(sink.reasons).Should(ConsistOf("test-reason"))
+		})
+	})
+	Describe("Stop", func() {
+		It("should not start a broadcaster if no event was emitted.", func() {
+			made := false
+			provider, err := recorder.NewProvider(cfg, scheme.Scheme, logr.DiscardLogger{}, func() (record.EventBroadcaster, bool) {
+				made = true
+				return record.NewBroadcaster(), true
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			provider.Stop(context.Background())
+			Expect(made).To(BeFalse())
+
+			By("dropping events emitted after the provider was stopped")
+			provider.GetEventRecorderFor("test").Event(&corev1.Pod{}, corev1.EventTypeNormal, "test", "test")
+			Expect(made).To(BeFalse())
+		})
 	})
+
 	Describe("GetEventRecorder", func() {
 		It("should return a recorder instance.", func() {
-			provider, err := recorder.NewProvider(cfg, scheme.Scheme, logr.DiscardLogger{}, makeBroadcaster)
+			provider, err := recorder.NewProvider(cfg, scheme.Scheme, logr.DiscardLogger{}, makeBroadcaster, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			recorder := provider.GetEventRecorderFor("test")
@@ -53,3 +85,33 @@ var _ = Describe("recorder.Provider", func() {
 		})
 	})
 })
+
+type fakeSink struct {
+	mu     sync.Mutex
+	events []*corev1.Event
+}
+
+func (s *fakeSink) reasons() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reasons := make([]string, 0, len(s.events))
+	for _, e := range s.events {
+		reasons = append(reasons, e.Reason)
+	}
+	return reasons
+}
+
+func (s *fakeSink) Create(e *corev1.Event) (*corev1.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, e)
+	return e, nil
+}
+
+func (s *fakeSink) Update(e *corev1.Event) (*corev1.Event, error) {
+	return e, nil
+}
+
+func (s *fakeSink) Patch(e *corev1.Event, _ []byte) (*corev1.Event, error) {
+	return e, nil
+}
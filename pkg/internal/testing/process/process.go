@@ -157,14 +157,18 @@ func (ps *State) Start(stdout, stderr io.Writer) (err error) {
 	ps.Cmd.Stdout = stdout
 	ps.Cmd.Stderr = stderr
 
-	ready := make(chan bool)
+	// ready is buffered so that the poller never blocks on it, even if we've
+	// already given up waiting.
+	ready := make(chan bool, 1)
 	timedOut := time.After(ps.StartTimeout)
 	pollerStopCh := make(stopChannel)
-	go pollURLUntilOK(ps.HealthCheck.URL, ps.HealthCheck.PollInterval, ready, pollerStopCh)
+	lastCheck := &healthCheckResult{}
+	go pollURLUntilOK(ps.HealthCheck.URL, ps.HealthCheck.PollInterval, ready, pollerStopCh, lastCheck)
 
 	ps.waitDone = make(chan struct{})
 
 	if err := ps.Cmd.Start(); err != nil {
+		close(pollerStopCh)
 		ps.errMu.Lock()
 		defer ps.errMu.Unlock()
 		ps.exited = true
@@ -185,21 +189,20 @@ func (ps *State) Start(stdout, stderr io.Writer) (err error) {
 		ps.ready = true
 		return nil
 	case <-ps.waitDone:
-		if pollerStopCh != nil {
-			close(pollerStopCh)
-		}
+		close(pollerStopCh)
+		_, exitErr := ps.Exited()
 		return fmt.Errorf("timeout waiting for process %s to start successfully "+
-			"(it may have failed to start, or stopped unexpectedly before becoming ready)",
-			path.Base(ps.Path))
+			"(it may have failed to start, or stopped unexpectedly before becoming ready): "+
+			"exit status: %v, last health check: %s",
+			path.Base(ps.Path), exitErr, lastCheck)
 	case <-timedOut:
-		if pollerStopCh != nil {
-			close(pollerStopCh)
-		}
+		close(pollerStopCh)
 		if ps.Cmd != nil {
 			// intentionally ignore this -- we might've crashed, failed to start, etc
 			ps.Cmd.Process.Signal(syscall.SIGTERM) //nolint:errcheck
 		}
-		return fmt.Errorf("timeout waiting for process %s to start", path.Base(ps.Path))
+		return fmt.Errorf("timeout waiting for process %s to start after %s, last health check: %s",
+			path.Base(ps.Path), ps.StartTimeout, lastCheck)
 	}
 }
 
@@ -212,7 +215,29 @@ func (ps *State) Exited() (bool, error) {
 	return ps.exited, ps.exitErr
 }
 
-func pollURLUntilOK(url url.URL, interval time.Duration, ready chan bool, stopCh stopChannel) {
+// healthCheckResult records the outcome of the most recent health check, so
+// that it can be reported if the process never becomes ready.
+type healthCheckResult struct {
+	mu     sync.Mutex
+	result string
+}
+
+func (r *healthCheckResult) set(result string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.result = result
+}
+
+func (r *healthCheckResult) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.result == "" {
+		return "none completed"
+	}
+	return r.result
+}
+
+func pollURLUntilOK(url url.URL, interval time.Duration, ready chan bool, stopCh stopChannel, lastCheck *healthCheckResult) {
 	client := &http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{
@@ -234,6 +259,9 @@ func pollURLUntilOK(url url.URL, interval time.Duration, ready chan bool, stopCh
 				ready <- true
 				return
 			}
+			lastCheck.set(fmt.Sprintf("GET %s returned %s", url.String(), res.Status))
+		} else {
+			lastCheck.set(err.Error())
 		}
 
 		select {
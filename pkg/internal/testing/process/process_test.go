@@ -161,6 +161,7 @@ var _ = Describe("Start method", func() {
 
 			err := processState.Start(nil, nil)
 			Expect(err).To(MatchError(ContainSubstring("timeout")))
+			Expect(err).To(MatchError(ContainSubstring("last health check: GET %s returned 500 Internal Server Error", processState.HealthCheck.URL.String())))
 
 			nrReceivedRequests := len(server.ReceivedRequests())
 			Expect(nrReceivedRequests).To(Equal(5))
@@ -169,6 +170,21 @@ var _ = Describe("Start method", func() {
 		})
 	})
 
+	Context("when the process exits before becoming ready", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", healthURLPath, ghttp.RespondWith(http.StatusServiceUnavailable, ""))
+		})
+		It("returns an error with the exit status and the last health check", func() {
+			processState.HealthCheck.Path = healthURLPath
+			processState.Args = []string{"-c", "sleep 0.3; exit 3"}
+			processState.StartTimeout = 10 * time.Second
+
+			err := processState.Start(nil, nil)
+			Expect(err).To(MatchError(ContainSubstring("exit status 3")))
+			Expect(err).To(MatchError(ContainSubstring("503 Service Unavailable")))
+		})
+	})
+
 	Context("when the healthcheck isn't even listening", func() {
 		BeforeEach(func() {
 			server.Close()
@@ -188,6 +204,7 @@ var _ = Describe("Start method", func() {
 
 			err = processState.Start(nil, nil)
 			Expect(err).To(MatchError(ContainSubstring("timeout")))
+			Expect(err).To(MatchError(ContainSubstring("connection refused")))
 		})
 	})
 
@@ -19,11 +19,9 @@ package fake
 import (
 	"context"
 	"encoding/json"
-	"os"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"sigs.k8s.io/controller-runtime/pkg/leaderelection"
@@ -33,12 +31,10 @@ import (
 // NewResourceLock creates a new ResourceLock for use in testing
 // leader election.
 func NewResourceLock(config *rest.Config, recorderProvider recorder.Provider, options leaderelection.Options) (resourcelock.Interface, error) {
-	// Leader id, needs to be unique
-	id, err := os.Hostname()
+	id, err := leaderelection.Identity(options)
 	if err != nil {
 		return nil, err
 	}
-	id = id + "_" + string(uuid.NewUUID())
 
 	return &ResourceLock{
 		id: id,
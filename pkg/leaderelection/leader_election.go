@@ -48,8 +48,20 @@ type Options struct {
 	// LeaderElectionID determines the name of the resource that leader election
 	// will use for holding the leader lock.
 	LeaderElectionID string
+
+	// LeaderElectionIdentity is the identity of this participant in the lock record.
+	// It must be unique among all participants.  Defaults to the hostname followed by
+	// a random UUID.
+	LeaderElectionIdentity string
 }
 
+// ResourceLockProvider creates the resource lock used for leader election.  Custom
+// providers can back leader election by something other than the Kubernetes API, e.g.
+// an external key-value store.  They should honor LeaderElectionIdentity if it is set.
+type ResourceLockProvider func(config *rest.Config, recorderProvider recorder.Provider, options Options) (resourcelock.Interface, error)
+
+var _ ResourceLockProvider = NewResourceLock
+
 // NewResourceLock creates a new resource lock for use in a leader election loop.
 func NewResourceLock(config *rest.Config, recorderProvider recorder.Provider, options Options) (resourcelock.Interface, error) {
 	if !options.LeaderElection {
@@ -77,15 +89,19 @@ func NewResourceLock(config *rest.Config, recorderProvider recorder.Provider, op
 		}
 	}
 
-	// Leader id, needs to be unique
-	id, err := os.Hostname()
+	id, err := Identity(options)
 	if err != nil {
 		return nil, err
 	}
-	id = id + "_" + string(uuid.NewUUID())
 
-	// Construct client for leader election
-	client, err := kubernetes.NewForConfig(rest.AddUserAgent(config, "leader-election"))
+	// Construct client for leader election, keeping the User-Agent of the config if it has one
+	userAgent := config.UserAgent
+	if userAgent == "" {
+		userAgent = rest.DefaultKubernetesUserAgent()
+	}
+	config = rest.CopyConfig(config)
+	config.UserAgent = userAgent + "/leader-election"
+	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +117,21 @@ func NewResourceLock(config *rest.Config, recorderProvider recorder.Provider, op
 		})
 }
 
+// Identity returns options.LeaderElectionIdentity, or if that is empty a new
+// identity made of the hostname and a random UUID.
+func Identity(options Options) (string, error) {
+	if options.LeaderElectionIdentity != "" {
+		return options.LeaderElectionIdentity, nil
+	}
+
+	// Leader id, needs to be unique
+	id, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+	return id + "_" + string(uuid.NewUUID()), nil
+}
+
 func getInClusterNamespace() (string, error) {
 	// Check whether the namespace file exists.
 	// If not, we are not running in cluster so can't guess the namespace.
@@ -25,6 +25,16 @@ limitations under the License.
 // to loggers.  When the implementation is set using SetLogger, these
 // "promises" will be converted over to real loggers.
 //
+// Logger Hierarchy
+//
+// Loggers form a hierarchy of names.  All loggers used inside
+// controller-runtime descend from Log.WithName("controller-runtime"), with
+// one child per subsystem (e.g. "controller-runtime.manager" or
+// "controller-runtime.webhook"), so their output can be told apart from (and
+// filtered independently of) the loggers of the program itself.  Programs
+// are expected to derive their own loggers from Log the same way, e.g.
+// Log.WithName("setup").
+//
 // Logr
 //
 // All logging in controller-runtime is structured, using a set of interfaces
@@ -35,6 +45,10 @@ package log
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
 	"sync"
 	"time"
 
@@ -52,7 +66,9 @@ func SetLogger(l logr.Logger) {
 
 // It is safe to assume that if this wasn't set within the first 30 seconds of a binaries
 // lifetime, it will never get set. The DelegatingLogger causes a high number of memory
-// allocations when not given an actual Logger, so we set a NullLogger to avoid that.
+// allocations when not given an actual Logger, so we set a logger discarding all logs
+// to avoid that.  As logs silently getting lost is hard to diagnose, that logger
+// prints a warning on its first use.
 //
 // We need to keep the DelegatingLogger because we have various inits() that get a logger from
 // here. They will always get executed before any code that imports controller-runtime
@@ -64,11 +80,58 @@ func init() {
 		loggerWasSetLock.Lock()
 		defer loggerWasSetLock.Unlock()
 		if !loggerWasSet {
-			Log.Fulfill(NullLogger{})
+			Log.Fulfill(newUnsetLogger(os.Stderr))
 		}
 	}()
 }
 
+// unsetLogger is a logr.Logger that discards all logs, like NullLogger, but
+// prints a warning including the caller's stack the first time it is used.
+type unsetLogger struct {
+	NullLogger
+	warning *unsetLoggerWarning
+}
+
+type unsetLoggerWarning struct {
+	once sync.Once
+	out  io.Writer
+}
+
+func newUnsetLogger(out io.Writer) logr.Logger {
+	return unsetLogger{warning: &unsetLoggerWarning{out: out}}
+}
+
+func (l unsetLogger) warn() {
+	l.warning.once.Do(func() {
+		fmt.Fprintf(l.warning.out, "[controller-runtime] log.SetLogger(...) was never called, logs will not be displayed.\nDetected at:\n%s", debug.Stack())
+	})
+}
+
+// Info implements logr.Logger.
+func (l unsetLogger) Info(_ string, _ ...interface{}) {
+	l.warn()
+}
+
+// Error implements logr.Logger.
+func (l unsetLogger) Error(_ error, _ string, _ ...interface{}) {
+	l.warn()
+}
+
+// V implements logr.Logger.
+func (l unsetLogger) V(_ int) logr.Logger {
+	return l
+}
+
+// WithName implements logr.Logger.
+func (l unsetLogger) WithName(_ string) logr.Logger {
+	return l
+}
+
+// WithValues implements logr.Logger.
+func (l unsetLogger) WithValues(_ ...interface{}) logr.Logger {
+	return l
+}
+
 var (
 	loggerWasSetLock sync.Mutex
 	loggerWasSet     bool
@@ -78,7 +141,7 @@ var (
 // to another logr.Logger. You *must* call SetLogger to
 // get any actual logging. If SetLogger is not called within
 // the first 30 seconds of a binaries lifetime, it will get
-// set to a NullLogger.
+// set to a logger discarding all logs, which warns about that once.
 var Log = NewDelegatingLogger(NullLogger{})
 
 // FromContext returns a logger with predefined values from a context.Context.
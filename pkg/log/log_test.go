@@ -17,6 +17,7 @@ limitations under the License.
 package log
 
 import (
+	"bytes"
 	"context"
 	"errors"
 
@@ -286,6 +287,29 @@ var _ = Describe("logging", func() {
 		})
 	})
 
+	Describe("unset logger", func() {
+		It("should warn once with the caller when used", func() {
+			out := &bytes.Buffer{}
+			log := newUnsetLogger(out).WithName("test").WithValues("tag", "value")
+
+			log.Info("msg 1")
+			Expect(out.String()).To(ContainSubstring("log.SetLogger(...) was never called"))
+			Expect(out.String()).To(ContainSubstring("log_test.go"))
+
+			warning := out.String()
+			log.V(1).Info("msg 2")
+			log.Error(errors.New("some error"), "msg 3")
+			Expect(out.String()).To(Equal(warning))
+		})
+
+		It("should not warn if it's never used", func() {
+			out := &bytes.Buffer{}
+			log := newUnsetLogger(out)
+			Expect(log.Enabled()).To(BeFalse())
+			Expect(out.String()).To(BeEmpty())
+		})
+	})
+
 	Describe("logger from context", func() {
 		It("should return default logger when context is empty", func() {
 			gotLog := FromContext(context.Background())
@@ -128,3 +128,46 @@ func (ev *stackTraceFlag) String() string {
 func (ev *stackTraceFlag) Type() string {
 	return "level"
 }
+
+type timeEncodingFlag struct {
+	setFunc func(zapcore.TimeEncoder)
+	value   string
+}
+
+var _ flag.Value = &timeEncodingFlag{}
+
+func (ev *timeEncodingFlag) String() string {
+	return ev.value
+}
+
+func (ev *timeEncodingFlag) Type() string {
+	return "time-encoding"
+}
+
+func (ev *timeEncodingFlag) Set(flagValue string) error {
+	encoder, err := getTimeEncoder(flagValue)
+	if err != nil {
+		return err
+	}
+	ev.setFunc(encoder)
+	ev.value = flagValue
+	return nil
+}
+
+func getTimeEncoder(flagValue string) (zapcore.TimeEncoder, error) {
+	switch strings.ToLower(flagValue) {
+	case "epoch":
+		return zapcore.EpochTimeEncoder, nil
+	case "millis":
+		return zapcore.EpochMillisTimeEncoder, nil
+	case "nano":
+		return zapcore.EpochNanosTimeEncoder, nil
+	case "iso8601":
+		return zapcore.ISO8601TimeEncoder, nil
+	case "rfc3339":
+		return zapcore.RFC3339TimeEncoder, nil
+	case "rfc3339nano":
+		return zapcore.RFC3339NanoTimeEncoder, nil
+	}
+	return nil, fmt.Errorf("invalid time-encoding value \"%s\"", flagValue)
+}
@@ -164,6 +164,11 @@ type Options struct {
 	// is true and Error otherwise.
 	// See Level for the relationship of zap log level to logr verbosity.
 	StacktraceLevel zapcore.LevelEnabler
+	// TimeEncoder specifies the encoder for the timestamps in log messages.
+	// Defaults to the one of the Zap development or production encoder
+	// config, depending on Development.
+	// Note that the TimeEncoder is not used when the Encoder option is already set.
+	TimeEncoder zapcore.TimeEncoder
 	// ZapOpts allows passing arbitrary zap.Options to configure on the
 	// underlying Zap logger.
 	ZapOpts []zap.Option
@@ -212,6 +217,13 @@ func (o *Options) addDefaults() {
 				}))
 		}
 	}
+	if o.TimeEncoder != nil {
+		f := func(ecfg *zapcore.EncoderConfig) {
+			ecfg.EncodeTime = o.TimeEncoder
+		}
+		// prepend instead of append it in case someone adds a time encoder option in it
+		o.EncoderConfigOptions = append([]EncoderConfigOption{f}, o.EncoderConfigOptions...)
+	}
 	if o.Encoder == nil {
 		o.Encoder = o.NewEncoder(o.EncoderConfigOptions...)
 	}
@@ -244,6 +256,7 @@ func NewRaw(opts ...Opts) *zap.Logger {
 //  zap-log-level:  Zap Level to configure the verbosity of logging. Can be one of 'debug', 'info', 'error',
 //			       or any integer value > 0 which corresponds to custom debug levels of increasing verbosity")
 //  zap-stacktrace-level: Zap Level at and above which stacktraces are captured (one of 'info', 'error' or 'panic')
+//  zap-time-encoding: Zap time encoding (one of 'epoch', 'millis', 'nano', 'iso8601', 'rfc3339' or 'rfc3339nano')
 func (o *Options) BindFlags(fs *flag.FlagSet) {
 	// Set Development mode value
 	fs.BoolVar(&o.Development, "zap-devel", o.Development,
@@ -273,6 +286,15 @@ func (o *Options) BindFlags(fs *flag.FlagSet) {
 	}
 	fs.Var(&stackVal, "zap-stacktrace-level",
 		"Zap Level at and above which stacktraces are captured (one of 'info', 'error', 'panic').")
+
+	// Set the time encoding
+	var timeEncoderVal timeEncodingFlag
+	timeEncoderVal.setFunc = func(fromFlag zapcore.TimeEncoder) {
+		o.TimeEncoder = fromFlag
+	}
+	fs.Var(&timeEncoderVal, "zap-time-encoding",
+		"Zap time encoding (one of 'epoch', 'millis', 'nano', 'iso8601', 'rfc3339' or 'rfc3339nano'). "+
+			"Defaults to 'epoch' in production mode and 'iso8601' in development mode.")
 }
 
 // UseFlagOptions configures the logger to use the Options set by parsing zap option flags from the CLI.
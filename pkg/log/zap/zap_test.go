@@ -20,7 +20,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"flag"
+	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
@@ -438,6 +440,31 @@ var _ = Describe("Zap log level flag options setup", func() {
 
 	})
 
+	Context("with zap-time-encoding flag provided", func() {
+		It("Should set the time encoder to the given one.", func() {
+			args := []string{"--zap-time-encoding=rfc3339"}
+			fromFlags.BindFlags(&fs)
+			Expect(fs.Parse(args)).To(Succeed())
+			logOut := new(bytes.Buffer)
+			log := New(UseFlagOptions(&fromFlags), WriteTo(logOut))
+			log.Info("This is a test message")
+
+			res := map[string]interface{}{}
+			Expect(json.Unmarshal(logOut.Bytes(), &res)).To(Succeed())
+			Expect(res["ts"]).To(BeAssignableToTypeOf(""))
+			_, err := time.Parse(time.RFC3339, res["ts"].(string))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should fail on an invalid time encoding.", func() {
+			args := []string{"--zap-time-encoding=invalid"}
+			fs := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			fromFlags.BindFlags(fs)
+			Expect(fs.Parse(args)).NotTo(Succeed())
+		})
+	})
+
 	Context("with only -zap-devel flag provided", func() {
 		It("Should set dev=true.", func() {
 			args := []string{"--zap-devel=true"}
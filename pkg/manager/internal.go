@@ -36,6 +36,7 @@ import (
 	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/config/v1alpha1"
@@ -64,6 +65,22 @@ type controllerManager struct {
 	// cluster holds a variety of methods to interact with a cluster. Required.
 	cluster cluster.Cluster
 
+	// clusters holds the secondary clusters registered with AddCluster, by name.
+	// It has its own lock so that it can be read while mu is held by Add.
+	clustersLock sync.Mutex
+	clusters     map[string]cluster.Cluster
+
+	// engagedClusters holds the clusters engaged by a cluster.Provider, by name.
+	// Protected by clustersLock.
+	engagedClusters map[string]*engagedCluster
+	// engageCtx is the context engaged clusters are started with. It is set when the
+	// manager is started and is nil before. Protected by clustersLock.
+	engageCtx context.Context
+	// engageStopped is set once the stop procedure is engaged, after which no more
+	// clusters can be engaged. It doesn't use mu, which is held by the stop procedure
+	// while it waits for the runnables. Protected by clustersLock.
+	engageStopped bool
+
 	// leaderElectionRunnables is the set of Controllers that the controllerManager injects deps into and Starts.
 	// These Runnables are managed by lead election.
 	leaderElectionRunnables []Runnable
@@ -83,9 +100,20 @@ type controllerManager struct {
 	// on shutdown
 	leaderElectionReleaseOnCancel bool
 
+	// leaderElectionHealthz reports whether this manager, while being the leader,
+	// renews its lease in time.  Nil unless a health check tolerance is configured.
+	leaderElectionHealthz *leaderelection.HealthzAdaptor
+
 	// metricsListener is used to serve prometheus metrics
 	metricsListener net.Listener
 
+	// metricsCertWatcher provides the serving certificate of the metrics
+	// listener, if metrics are served securely.
+	metricsCertWatcher *certwatcher.CertWatcher
+
+	// metricsFilter is wrapped around the handlers of the metrics server, if set.
+	metricsFilter metrics.Filter
+
 	// metricsExtraHandlers contains extra handlers to register on http server that serves metrics.
 	metricsExtraHandlers map[string]http.Handler
 
@@ -210,10 +238,17 @@ func (cm *controllerManager) Add(r Runnable) error {
 		shouldStart = cm.started
 		cm.nonLeaderElectionRunnables = append(cm.nonLeaderElectionRunnables, r)
 	} else if hasCache, ok := r.(hasCache); ok {
+		// Caches added after the manager started, e.g. secondary clusters,
+		// have missed waitForCache and must be started right away.
+		shouldStart = cm.started
 		cm.caches = append(cm.caches, hasCache)
 	} else {
 		shouldStart = cm.startedLeader
 		cm.leaderElectionRunnables = append(cm.leaderElectionRunnables, r)
+
+		if warmupRunnable, ok := r.(WarmupRunnable); ok && cm.started && !cm.startedLeader {
+			cm.startWarmup(warmupRunnable)
+		}
 	}
 
 	if shouldStart {
@@ -224,6 +259,139 @@ func (cm *controllerManager) Add(r Runnable) error {
 	return nil
 }
 
+// AddCluster registers c under name and adds it to the manager's Runnables.
+func (cm *controllerManager) AddCluster(name string, c cluster.Cluster) error {
+	if name == "" {
+		return errors.New("cluster name must not be empty")
+	}
+	if c == nil {
+		return errors.New("must provide a non-nil Cluster")
+	}
+
+	cm.clustersLock.Lock()
+	if _, ok := cm.clusters[name]; ok {
+		cm.clustersLock.Unlock()
+		return fmt.Errorf("cluster %q is already registered", name)
+	}
+	if cm.clusters == nil {
+		cm.clusters = map[string]cluster.Cluster{}
+	}
+	cm.clusters[name] = c
+	cm.clustersLock.Unlock()
+
+	if err := cm.Add(c); err != nil {
+		cm.clustersLock.Lock()
+		delete(cm.clusters, name)
+		cm.clustersLock.Unlock()
+		return err
+	}
+	return nil
+}
+
+// GetCluster returns the cluster registered under name with AddCluster or Engage.
+func (cm *controllerManager) GetCluster(name string) (cluster.Cluster, error) {
+	cm.clustersLock.Lock()
+	defer cm.clustersLock.Unlock()
+	c, ok := cm.clusters[name]
+	if !ok {
+		return nil, fmt.Errorf("no cluster registered with name %q", name)
+	}
+	return c, nil
+}
+
+// engagedCluster is a cluster engaged by a cluster.Provider.
+type engagedCluster struct {
+	cancel context.CancelFunc
+	// stopped is closed once the Start method of the cluster returned.
+	stopped chan struct{}
+}
+
+// Engage implements cluster.Aware. It starts cl and registers it under name
+// until it is disengaged or the manager stops. Clusters can only be engaged
+// while the manager is running.
+func (cm *controllerManager) Engage(name string, cl cluster.Cluster) error {
+	if name == "" {
+		return errors.New("cluster name must not be empty")
+	}
+	if cl == nil {
+		return errors.New("must provide a non-nil Cluster")
+	}
+
+	// Hold clustersLock until the cluster was added to waitForRunnable, so that
+	// we don't race with the stop procedure waiting for the runnables.
+	cm.clustersLock.Lock()
+	defer cm.clustersLock.Unlock()
+	if cm.engageCtx == nil {
+		return errors.New("can't engage a cluster before the manager is started")
+	}
+	if cm.engageStopped || cm.engageCtx.Err() != nil {
+		return errors.New("can't engage a cluster as stop procedure is already engaged")
+	}
+
+	if _, ok := cm.clusters[name]; ok {
+		return fmt.Errorf("cluster %q is already registered", name)
+	}
+	if cm.clusters == nil {
+		cm.clusters = map[string]cluster.Cluster{}
+	}
+	if cm.engagedClusters == nil {
+		cm.engagedClusters = map[string]*engagedCluster{}
+	}
+
+	ctx, cancel := context.WithCancel(cm.engageCtx)
+	engaged := &engagedCluster{cancel: cancel, stopped: make(chan struct{})}
+	cm.clusters[name] = cl
+	cm.engagedClusters[name] = engaged
+
+	// A cluster that goes away must not take the manager down with it,
+	// so errors are only logged.
+	cm.waitForRunnable.Add(1)
+	go func() {
+		defer cm.waitForRunnable.Done()
+		defer close(engaged.stopped)
+		if err := cl.Start(ctx); err != nil {
+			cm.logger.Error(err, "engaged cluster stopped with an error", "cluster", name)
+		}
+	}()
+	return nil
+}
+
+// Disengage implements cluster.Aware. It stops the cluster engaged under name
+// and waits for it to stop.
+func (cm *controllerManager) Disengage(name string) error {
+	cm.clustersLock.Lock()
+	engaged, ok := cm.engagedClusters[name]
+	if ok {
+		delete(cm.engagedClusters, name)
+		delete(cm.clusters, name)
+	}
+	cm.clustersLock.Unlock()
+	if !ok {
+		return fmt.Errorf("no cluster engaged with name %q", name)
+	}
+
+	engaged.cancel()
+	<-engaged.stopped
+	return nil
+}
+
+// clusterProviderRunnable runs a cluster.Provider against the manager.
+type clusterProviderRunnable struct {
+	provider cluster.Provider
+	aware    cluster.Aware
+}
+
+// Start implements Runnable.
+func (r *clusterProviderRunnable) Start(ctx context.Context) error {
+	return r.provider.Run(ctx, r.aware)
+}
+
+// NeedLeaderElection implements LeaderElectionRunnable. Clusters are engaged
+// on every replica so that their caches are warm when it becomes the leader.
+func (r *clusterProviderRunnable) NeedLeaderElection() bool {
+	return false
+}
+
 // Deprecated: use the equivalent Options field to set a field. This method will be removed in v0.10.
 func (cm *controllerManager) SetFields(i interface{}) error {
 	if err := cm.cluster.SetFields(i); err != nil {
@@ -238,6 +406,11 @@ func (cm *controllerManager) SetFields(i interface{}) error {
 	if _, err := inject.LoggerInto(cm.logger, i); err != nil {
 		return err
 	}
+	if s, ok := i.(ClusterGetterInjectable); ok {
+		if err := s.InjectClusterGetter(cm); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -375,8 +548,23 @@ func (cm *controllerManager) serveMetrics() {
 		}
 	}()
 
+	var serverHandler http.Handler = mux
+	if cm.metricsFilter != nil {
+		var err error
+		serverHandler, err = cm.metricsFilter(cm.logger.WithName("metrics"), mux)
+		if err != nil {
+			// The listener is only closed by the server, which won't be started.
+			_ = cm.metricsListener.Close()
+			cm.errChan <- fmt.Errorf("unable to apply metrics filter: %w", err)
+			return
+		}
+	}
+
 	server := http.Server{
-		Handler: mux,
+		Handler: serverHandler,
+	}
+	if cm.metricsCertWatcher != nil {
+		cm.startRunnable(RunnableFunc(cm.metricsCertWatcher.Start))
 	}
 	// Run the server
 	cm.startRunnable(RunnableFunc(func(_ context.Context) error {
@@ -437,6 +625,9 @@ func (cm *controllerManager) Start(ctx context.Context) (err error) {
 		return fmt.Errorf("failed to add cluster to runnables: %w", err)
 	}
 	cm.internalCtx, cm.internalCancel = context.WithCancel(ctx)
+	cm.clustersLock.Lock()
+	cm.engageCtx = cm.internalCtx
+	cm.clustersLock.Unlock()
 
 	// This chan indicates that stop is complete, in other words all runnables have returned or timeout on stop request
 	stopComplete := make(chan struct{})
@@ -513,6 +704,11 @@ func (cm *controllerManager) engageStopProcedure(stopComplete <-chan struct{}) e
 	}
 	defer shutdownCancel()
 
+	// Reject clusters engaged from now on, before waiting for the runnables.
+	cm.clustersLock.Lock()
+	cm.engageStopped = true
+	cm.clustersLock.Unlock()
+
 	// Cancel the internal stop channel and wait for the procedures to stop and complete.
 	close(cm.internalProceduresStop)
 	cm.internalCancel()
@@ -602,6 +798,16 @@ func (cm *controllerManager) startNonLeaderElectionRunnables() {
 		// Write any Start errors to a channel so we can return them
 		cm.startRunnable(c)
 	}
+
+	// Warm up the leader election Runnables, so that they are ready to go as soon as we
+	// become the leader.
+	if !cm.startedLeader {
+		for _, c := range cm.leaderElectionRunnables {
+			if warmupRunnable, ok := c.(WarmupRunnable); ok {
+				cm.startWarmup(warmupRunnable)
+			}
+		}
+	}
 }
 
 func (cm *controllerManager) startLeaderElectionRunnables() {
@@ -671,6 +877,7 @@ func (cm *controllerManager) startLeaderElection() (err error) {
 			OnStoppedLeading: cm.onStoppedLeading,
 		},
 		ReleaseOnCancel: cm.leaderElectionReleaseOnCancel,
+		WatchDog:        cm.leaderElectionHealthz,
 	})
 	if err != nil {
 		return err
@@ -685,10 +892,46 @@ func (cm *controllerManager) startLeaderElection() (err error) {
 	return nil
 }
 
+// addLeaderElectionHealthCheck adds a healthz check that fails if this manager
+// is the leader but hasn't renewed its lease for longer than the lease duration
+// plus the given tolerance.
+func (cm *controllerManager) addLeaderElectionHealthCheck(tolerance time.Duration) error {
+	cm.leaderElectionHealthz = leaderelection.NewLeaderHealthzAdaptor(tolerance)
+	return cm.AddHealthzCheck("leader-election", cm.leaderElectionHealthz.Check)
+}
+
+// validateLeaderElectionTimings returns an error for timings the leader elector
+// would reject, so that they are reported by New rather than once the manager
+// starts.
+func validateLeaderElectionTimings(leaseDuration, renewDeadline, retryPeriod time.Duration) error {
+	if retryPeriod <= 0 {
+		return fmt.Errorf("leader election RetryPeriod must be greater than zero, got %s", retryPeriod)
+	}
+	if leaseDuration <= renewDeadline {
+		return fmt.Errorf("leader election LeaseDuration (%s) must be greater than RenewDeadline (%s)", leaseDuration, renewDeadline)
+	}
+	if minRenewDeadline := time.Duration(leaderelection.JitterFactor * float64(retryPeriod)); renewDeadline <= minRenewDeadline {
+		return fmt.Errorf("leader election RenewDeadline (%s) must be greater than %v times RetryPeriod (%s)", renewDeadline, leaderelection.JitterFactor, retryPeriod)
+	}
+	return nil
+}
+
 func (cm *controllerManager) Elected() <-chan struct{} {
 	return cm.elected
 }
 
+// startWarmup warms up the given runnable. Errors are only logged, as the runnable
+// is expected to surface them again once it is started.
+func (cm *controllerManager) startWarmup(r WarmupRunnable) {
+	cm.waitForRunnable.Add(1)
+	go func() {
+		defer cm.waitForRunnable.Done()
+		if err := r.Warmup(cm.internalCtx); err != nil {
+			cm.logger.Error(err, "failed to warm up runnable")
+		}
+	}()
+}
+
 func (cm *controllerManager) startRunnable(r Runnable) {
 	cm.waitForRunnable.Add(1)
 	go func() {
@@ -18,9 +18,12 @@ package manager
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
 	"time"
 
@@ -29,9 +32,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/config"
@@ -41,7 +44,6 @@ import (
 	intrec "sigs.k8s.io/controller-runtime/pkg/internal/recorder"
 	"sigs.k8s.io/controller-runtime/pkg/leaderelection"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
-	"sigs.k8s.io/controller-runtime/pkg/recorder"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
@@ -57,8 +59,19 @@ type Manager interface {
 	// implements the inject interface - e.g. inject.Client.
 	// Depending on if a Runnable implements LeaderElectionRunnable interface, a Runnable can be run in either
 	// non-leaderelection mode (always running) or leader election mode (managed by leader election if enabled).
+	// Secondary clusters created with cluster.New can be added as well; their caches are started and synced
+	// before any other Runnable, and controllers can watch them with source.NewKindWithCache.
 	Add(Runnable) error
 
+	// AddCluster registers a secondary cluster under the given name and adds it to the manager
+	// like Add does.  Components can look it up again through GetCluster, which lets a reconciler
+	// read from one cluster and write to another.
+	AddCluster(name string, c cluster.Cluster) error
+
+	// ClusterGetter returns the secondary clusters registered with AddCluster
+	// or engaged by Options.ClusterProvider.
+	ClusterGetter
+
 	// Elected is closed when this manager is elected leader of a group of
 	// managers, either because it won a leader election or because no leader
 	// election was configured.
@@ -136,6 +149,15 @@ type Options struct {
 	// If none is set, it defaults to log.Log global logger.
 	Logger logr.Logger
 
+	// UserAgent is set on the rest.Config used by all clients the manager creates,
+	// including the one returned by GetConfig, so that apiserver audit logs and
+	// API Priority and Fairness can attribute the requests to this manager.  It is
+	// also set on LeaderElectionConfig if that has no User-Agent of its own; the
+	// leader election client appends "/leader-election" to it.
+	// Defaults to the User-Agent of the given rest.Config, or if that is empty to
+	// the client-go default made of the binary name and version.
+	UserAgent string
+
 	// LeaderElection determines whether or not to use leader election when
 	// starting the manager.
 	LeaderElection bool
@@ -163,6 +185,25 @@ type Options struct {
 	// will use for holding the leader lock.
 	LeaderElectionID string
 
+	// LeaderElectionHealthCheckTolerance, if greater than zero, adds a "leader-election"
+	// check to the healthz endpoint that fails if this manager is the leader but
+	// hasn't renewed its lease for longer than LeaseDuration plus this tolerance.
+	// Wired into a liveness probe, this gets a wedged leader restarted instead of
+	// leaving the controllers without an active leader.  It has no effect if
+	// LeaderElection is disabled.
+	LeaderElectionHealthCheckTolerance time.Duration
+
+	// LeaderElectionIdentity overrides the identity of this manager in the leader
+	// election lock record.  It must be unique among all replicas.  Defaults to the
+	// hostname followed by a random UUID.
+	LeaderElectionIdentity string
+
+	// LeaderElectionResourceLockProvider creates the resource lock used for leader
+	// election, e.g. one backed by an external key-value store.  It is passed the
+	// leader election options of the manager.  Defaults to leaderelection.NewResourceLock,
+	// which creates the lock selected by LeaderElectionResourceLock.
+	LeaderElectionResourceLockProvider leaderelection.ResourceLockProvider
+
 	// LeaderElectionConfig can be specified to override the default configuration
 	// that is used to build the leader election client.
 	LeaderElectionConfig *rest.Config
@@ -183,6 +224,11 @@ type Options struct {
 	RenewDeadline *time.Duration
 	// RetryPeriod is the duration the LeaderElector clients should wait
 	// between tries of actions. Default is 2 seconds.
+	//
+	// When LeaderElection is enabled, New returns an error unless LeaseDuration
+	// is greater than RenewDeadline, and RenewDeadline is greater than RetryPeriod
+	// plus its jitter.  Shorter durations make failovers faster at the price of
+	// more requests to the apiserver.
 	RetryPeriod *time.Duration
 
 	// Namespace if specified restricts the manager's cache to watch objects in
@@ -198,6 +244,37 @@ type Options struct {
 	// It can be set to "0" to disable the metrics serving.
 	MetricsBindAddress string
 
+	// MetricsSecureServing enables serving metrics via https.  The server key
+	// and certificate are read from MetricsCertDir.  By default metrics are
+	// served via plain http.
+	MetricsSecureServing bool
+
+	// MetricsCertDir is the directory that contains the server key and
+	// certificate used when MetricsSecureServing is enabled.  If not set, they
+	// are looked up in {TempDir}/k8s-metrics-server/serving-certs.  The server
+	// key and certificate must be named tls.key and tls.crt, respectively.
+	// The certificate is reloaded when it changes on disk.
+	MetricsCertDir string
+
+	// MetricsFilterProvider provides a filter which is wrapped around all
+	// handlers of the metrics server, e.g. to authenticate and authorize
+	// requests.  It's called with the manager's rest config.  See
+	// filters.WithAuthenticationAndAuthorization for a filter using
+	// TokenReviews and SubjectAccessReviews.
+	MetricsFilterProvider func(c *rest.Config) (metrics.Filter, error)
+
+	// MetricsExtraHandlers contains extra handlers to register on the http
+	// server that serves metrics, keyed by path.  This is useful for exposing
+	// pprof or other debug endpoints without running another listener.  The
+	// builtin /metrics path can't be overridden.  Handlers can also be added
+	// later on with AddMetricsExtraHandler.
+	MetricsExtraHandlers map[string]http.Handler
+
+	// ClusterProvider, if set, is run by the manager on every replica once it
+	// has started, to add and remove secondary clusters at runtime.  Engaged
+	// clusters can be looked up with GetCluster by the name the provider gave them.
+	ClusterProvider cluster.Provider
+
 	// HealthProbeBindAddress is the TCP address that the controller should bind to
 	// for serving health probes
 	HealthProbeBindAddress string
@@ -253,6 +330,11 @@ type Options struct {
 	// is shorter than the lifetime of your process.
 	EventBroadcaster record.EventBroadcaster
 
+	// EventSink is where Events emitted by the manager are recorded to, e.g. to
+	// also forward them to another system or to drop them in tests.
+	// Defaults to the core v1 Events API of the cluster.
+	EventSink record.EventSink
+
 	// GracefulShutdownTimeout is the duration given to runnable to stop before the manager actually returns on stop.
 	// To disable graceful shutdown, set to time.Duration(0)
 	// To use graceful shutdown without timeout, set to a negative duration, e.G. time.Duration(-1)
@@ -271,8 +353,8 @@ type Options struct {
 	makeBroadcaster intrec.EventBroadcasterProducer
 
 	// Dependency injection for testing
-	newRecorderProvider    func(config *rest.Config, scheme *runtime.Scheme, logger logr.Logger, makeBroadcaster intrec.EventBroadcasterProducer) (*intrec.Provider, error)
-	newResourceLock        func(config *rest.Config, recorderProvider recorder.Provider, options leaderelection.Options) (resourcelock.Interface, error)
+	newRecorderProvider    func(config *rest.Config, scheme *runtime.Scheme, logger logr.Logger, makeBroadcaster intrec.EventBroadcasterProducer, sink record.EventSink) (*intrec.Provider, error)
+	newResourceLock        leaderelection.ResourceLockProvider
 	newMetricsListener     func(addr string) (net.Listener, error)
 	newHealthProbeListener func(addr string) (net.Listener, error)
 }
@@ -297,6 +379,19 @@ func (r RunnableFunc) Start(ctx context.Context) error {
 	return r(ctx)
 }
 
+// ClusterGetter returns named clusters.
+type ClusterGetter interface {
+	// GetCluster returns the cluster registered under the given name, or an error
+	// if no such cluster exists.
+	GetCluster(name string) (cluster.Cluster, error)
+}
+
+// ClusterGetterInjectable is implemented by components, e.g. Reconcilers, that want the
+// manager's ClusterGetter injected when they are added to the manager or to one of its controllers.
+type ClusterGetterInjectable interface {
+	InjectClusterGetter(ClusterGetter) error
+}
+
 // LeaderElectionRunnable knows if a Runnable needs to be run in the leader election mode.
 type LeaderElectionRunnable interface {
 	// NeedLeaderElection returns true if the Runnable needs to be run in the leader election mode.
@@ -304,11 +399,39 @@ type LeaderElectionRunnable interface {
 	NeedLeaderElection() bool
 }
 
+// WarmupRunnable knows how to warm up a Runnable that needs leader election before
+// this manager becomes the leader.
+type WarmupRunnable interface {
+	// Warmup is called on every replica once the caches of the manager have synced,
+	// regardless of whether this replica is the leader. It is expected to prepare the
+	// Runnable so that Start completes its startup quickly, e.g. by starting sources
+	// and waiting for them to sync, and must not block until the context is cancelled.
+	Warmup(context.Context) error
+}
+
 // New returns a new Manager for creating Controllers.
 func New(config *rest.Config, options Options) (Manager, error) {
 	// Set default values for options fields
 	options = setOptionsDefaults(options)
 
+	if options.LeaderElection {
+		if err := validateLeaderElectionTimings(*options.LeaseDuration, *options.RenewDeadline, *options.RetryPeriod); err != nil {
+			return nil, err
+		}
+	}
+
+	// Set the User-Agent on copies of the configs, to leave the caller's untouched.
+	if options.UserAgent != "" {
+		if config != nil {
+			config = rest.CopyConfig(config)
+			config.UserAgent = options.UserAgent
+		}
+		if options.LeaderElectionConfig != nil && options.LeaderElectionConfig.UserAgent == "" {
+			options.LeaderElectionConfig = rest.CopyConfig(options.LeaderElectionConfig)
+			options.LeaderElectionConfig.UserAgent = options.UserAgent
+		}
+	}
+
 	cluster, err := cluster.New(config, func(clusterOptions *cluster.Options) {
 		clusterOptions.Scheme = options.Scheme
 		clusterOptions.MapperProvider = options.MapperProvider
@@ -320,6 +443,7 @@ func New(config *rest.Config, options Options) (Manager, error) {
 		clusterOptions.ClientDisableCacheFor = options.ClientDisableCacheFor
 		clusterOptions.DryRunClient = options.DryRunClient
 		clusterOptions.EventBroadcaster = options.EventBroadcaster //nolint:staticcheck
+		clusterOptions.EventSink = options.EventSink
 	})
 	if err != nil {
 		return nil, err
@@ -328,7 +452,7 @@ func New(config *rest.Config, options Options) (Manager, error) {
 	// Create the recorder provider to inject event recorders for the components.
 	// TODO(directxman12): the log for the event provider should have a context (name, tags, etc) specific
 	// to the particular controller that it's being injected into, rather than a generic one like is here.
-	recorderProvider, err := options.newRecorderProvider(config, cluster.GetScheme(), options.Logger.WithName("events"), options.makeBroadcaster)
+	recorderProvider, err := options.newRecorderProvider(config, cluster.GetScheme(), options.Logger.WithName("events"), options.makeBroadcaster, options.EventSink)
 	if err != nil {
 		return nil, err
 	}
@@ -343,11 +467,30 @@ func New(config *rest.Config, options Options) (Manager, error) {
 		LeaderElectionResourceLock: options.LeaderElectionResourceLock,
 		LeaderElectionID:           options.LeaderElectionID,
 		LeaderElectionNamespace:    options.LeaderElectionNamespace,
+		LeaderElectionIdentity:     options.LeaderElectionIdentity,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Seed the extra endpoints exposed on the metrics http server with the ones
+	// passed in via options; more can be added with AddMetricsExtraHandler.
+	metricsExtraHandlers := make(map[string]http.Handler, len(options.MetricsExtraHandlers))
+	for path, handler := range options.MetricsExtraHandlers {
+		if path == defaultMetricsEndpoint {
+			return nil, fmt.Errorf("overriding builtin %s endpoint is not allowed", defaultMetricsEndpoint)
+		}
+		metricsExtraHandlers[path] = handler
+	}
+
+	var metricsFilter metrics.Filter
+	if options.MetricsFilterProvider != nil {
+		metricsFilter, err = options.MetricsFilterProvider(config)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create metrics filter: %w", err)
+		}
+	}
+
 	// Create the metrics listener. This will throw an error if the metrics bind
 	// address is invalid or already in use.
 	metricsListener, err := options.newMetricsListener(options.MetricsBindAddress)
@@ -355,8 +498,13 @@ func New(config *rest.Config, options Options) (Manager, error) {
 		return nil, err
 	}
 
-	// By default we have no extra endpoints to expose on metrics http server.
-	metricsExtraHandlers := make(map[string]http.Handler)
+	var metricsCertWatcher *certwatcher.CertWatcher
+	if metricsListener != nil && options.MetricsSecureServing {
+		metricsListener, metricsCertWatcher, err = secureMetricsListener(metricsListener, options.MetricsCertDir)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Create health probes listener. This will throw an error if the bind
 	// address is invalid or already in use.
@@ -365,11 +513,13 @@ func New(config *rest.Config, options Options) (Manager, error) {
 		return nil, err
 	}
 
-	return &controllerManager{
+	cm := &controllerManager{
 		cluster:                       cluster,
 		recorderProvider:              recorderProvider,
 		resourceLock:                  resourceLock,
 		metricsListener:               metricsListener,
+		metricsCertWatcher:            metricsCertWatcher,
+		metricsFilter:                 metricsFilter,
 		metricsExtraHandlers:          metricsExtraHandlers,
 		controllerOptions:             options.Controller,
 		logger:                        options.Logger,
@@ -388,7 +538,21 @@ func New(config *rest.Config, options Options) (Manager, error) {
 		internalProceduresStop:        make(chan struct{}),
 		leaderElectionStopped:         make(chan struct{}),
 		leaderElectionReleaseOnCancel: options.LeaderElectionReleaseOnCancel,
-	}, nil
+	}
+
+	if options.LeaderElection && options.LeaderElectionHealthCheckTolerance > 0 {
+		if err := cm.addLeaderElectionHealthCheck(options.LeaderElectionHealthCheckTolerance); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.ClusterProvider != nil {
+		if err := cm.Add(&clusterProviderRunnable{provider: options.ClusterProvider, aware: cm}); err != nil {
+			return nil, err
+		}
+	}
+
+	return cm, nil
 }
 
 // AndFrom will use a supplied type and convert to Options
@@ -453,6 +617,10 @@ func (o Options) AndFrom(loader config.ControllerManagerConfiguration) (Options,
 		if len(o.Controller.GroupKindConcurrency) == 0 && len(newObj.Controller.GroupKindConcurrency) > 0 {
 			o.Controller.GroupKindConcurrency = newObj.Controller.GroupKindConcurrency
 		}
+
+		if o.Controller.SkipNameValidation == nil && newObj.Controller.SkipNameValidation != nil {
+			o.Controller.SkipNameValidation = newObj.Controller.SkipNameValidation
+		}
 	}
 
 	return o, nil
@@ -515,6 +683,9 @@ func defaultHealthProbeListener(addr string) (net.Listener, error) {
 // setOptionsDefaults set default values for Options fields.
 func setOptionsDefaults(options Options) Options {
 	// Allow newResourceLock to be mocked
+	if options.newResourceLock == nil {
+		options.newResourceLock = options.LeaderElectionResourceLockProvider
+	}
 	if options.newResourceLock == nil {
 		options.newResourceLock = leaderelection.NewResourceLock
 	}
@@ -577,3 +748,22 @@ func setOptionsDefaults(options Options) Options {
 
 	return options
 }
+
+// secureMetricsListener wraps the metrics listener so that it serves TLS, using
+// the key and certificate in certDir.
+func secureMetricsListener(ln net.Listener, certDir string) (net.Listener, *certwatcher.CertWatcher, error) {
+	if certDir == "" {
+		certDir = filepath.Join(os.TempDir(), "k8s-metrics-server", "serving-certs")
+	}
+	watcher, err := certwatcher.New(filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key"))
+	if err != nil {
+		_ = ln.Close()
+		return nil, nil, fmt.Errorf("unable to load metrics serving certificate: %w", err)
+	}
+	cfg := &tls.Config{
+		GetCertificate: watcher.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+		MinVersion:     tls.VersionTLS12,
+	}
+	return tls.NewListener(ln, cfg), watcher, nil
+}
@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"path"
 	"sync"
 	"sync/atomic"
@@ -39,6 +40,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
@@ -110,7 +112,7 @@ var _ = Describe("manger.Manager", func() {
 
 		It("should return an error it can't create a recorder.Provider", func() {
 			m, err := New(cfg, Options{
-				newRecorderProvider: func(_ *rest.Config, _ *runtime.Scheme, _ logr.Logger, _ intrec.EventBroadcasterProducer) (*intrec.Provider, error) {
+				newRecorderProvider: func(_ *rest.Config, _ *runtime.Scheme, _ logr.Logger, _ intrec.EventBroadcasterProducer, _ record.EventSink) (*intrec.Provider, error) {
 					return nil, fmt.Errorf("expected error")
 				},
 			})
@@ -346,6 +348,63 @@ var _ = Describe("manger.Manager", func() {
 
 				Expect(cm.gracefulShutdownTimeout.Nanoseconds()).To(Equal(int64(0)))
 			})
+			It("should add a leader election healthz check if a tolerance is set", func() {
+				m, err := New(cfg, Options{
+					LeaderElection:                     true,
+					LeaderElectionNamespace:            "default",
+					LeaderElectionID:                   "test-leader-election-id",
+					LeaderElectionHealthCheckTolerance: 5 * time.Second,
+					newResourceLock:                    fakeleaderelection.NewResourceLock,
+					HealthProbeBindAddress:             "0",
+					MetricsBindAddress:                 "0",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				cm, ok := m.(*controllerManager)
+				Expect(ok).To(BeTrue())
+				Expect(cm.leaderElectionHealthz).NotTo(BeNil())
+				Expect(cm.healthzHandler.Checks).To(HaveKey("leader-election"))
+				Expect(cm.healthzHandler.Checks["leader-election"](nil)).To(Succeed())
+			})
+
+			It("should not add a leader election healthz check by default", func() {
+				m, err := New(cfg, Options{
+					LeaderElection:          true,
+					LeaderElectionNamespace: "default",
+					LeaderElectionID:        "test-leader-election-id",
+					newResourceLock:         fakeleaderelection.NewResourceLock,
+					HealthProbeBindAddress:  "0",
+					MetricsBindAddress:      "0",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				cm, ok := m.(*controllerManager)
+				Expect(ok).To(BeTrue())
+				Expect(cm.leaderElectionHealthz).To(BeNil())
+			})
+
+			It("should use the LeaderElectionResourceLockProvider with the configured identity", func() {
+				var gotOptions leaderelection.Options
+				m, err := New(cfg, Options{
+					LeaderElection:          true,
+					LeaderElectionNamespace: "default",
+					LeaderElectionID:        "test-leader-election-id",
+					LeaderElectionIdentity:  "replica-1",
+					LeaderElectionResourceLockProvider: func(config *rest.Config, recorderProvider recorder.Provider, options leaderelection.Options) (resourcelock.Interface, error) {
+						gotOptions = options
+						return fakeleaderelection.NewResourceLock(config, recorderProvider, options)
+					},
+					HealthProbeBindAddress: "0",
+					MetricsBindAddress:     "0",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(gotOptions.LeaderElectionIdentity).To(Equal("replica-1"))
+
+				cm, ok := m.(*controllerManager)
+				Expect(ok).To(BeTrue())
+				Expect(cm.resourceLock.Identity()).To(Equal("replica-1"))
+			})
+
 			It("should default ID to controller-runtime if ID is not set", func() {
 				var rl resourcelock.Interface
 				m1, err := New(cfg, Options{
@@ -734,6 +793,40 @@ var _ = Describe("manger.Manager", func() {
 				<-runnableWasStarted
 			})
 
+			It("should start additional clusters that are added after the manager has started", func() {
+				m, err := New(cfg, options)
+				Expect(err).NotTo(HaveOccurred())
+				for _, cb := range callbacks {
+					cb(m)
+				}
+
+				runnableWasStarted := make(chan struct{})
+				Expect(m.Add(RunnableFunc(func(ctx context.Context) error {
+					close(runnableWasStarted)
+					<-ctx.Done()
+					return nil
+				}))).To(Succeed())
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).ToNot(HaveOccurred())
+				}()
+				<-runnableWasStarted
+
+				additionalClusterCache := &startSignalingInformer{Cache: &informertest.FakeInformers{}}
+				additionalCluster, err := cluster.New(cfg, func(o *cluster.Options) {
+					o.NewCache = func(_ *rest.Config, _ cache.Options) (cache.Cache, error) {
+						return additionalClusterCache, nil
+					}
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(m.Add(additionalCluster)).To(Succeed())
+
+				Eventually(additionalClusterCache.started).Should(BeTrue())
+			})
+
 			It("should return an error if any Components fail to Start", func() {
 				m, err := New(cfg, options)
 				Expect(err).NotTo(HaveOccurred())
@@ -1174,6 +1267,100 @@ var _ = Describe("manger.Manager", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(body)).To(Equal("Some debug info"))
 			})
+
+			It("should serve extra endpoints passed in via options", func() {
+				opts.MetricsBindAddress = ":0"
+				opts.MetricsExtraHandlers = map[string]http.Handler{
+					"/debug": http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+						_, _ = w.Write([]byte("Some debug info"))
+					}),
+				}
+				m, err := New(cfg, opts)
+				Expect(err).NotTo(HaveOccurred())
+
+				// Should error when we add another extra endpoint on the path registered via options.
+				err = m.AddMetricsExtraHandler("/debug", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					_, _ = w.Write([]byte("Another debug info"))
+				}))
+				Expect(err).To(HaveOccurred())
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).NotTo(HaveOccurred())
+				}()
+
+				endpoint := fmt.Sprintf("http://%s/debug", listener.Addr().String())
+				resp, err := http.Get(endpoint)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(resp.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(body)).To(Equal("Some debug info"))
+			})
+
+			It("should not allow overriding the metrics endpoint via options", func() {
+				opts.MetricsBindAddress = ":0"
+				opts.MetricsExtraHandlers = map[string]http.Handler{
+					"/metrics": http.NotFoundHandler(),
+				}
+				_, err := New(cfg, opts)
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should wrap the metrics endpoint with the configured filter", func() {
+				opts.MetricsBindAddress = ":0"
+				opts.MetricsFilterProvider = func(_ *rest.Config) (metrics.Filter, error) {
+					return func(_ logr.Logger, _ http.Handler) (http.Handler, error) {
+						return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+							w.WriteHeader(http.StatusForbidden)
+						}), nil
+					}, nil
+				}
+				m, err := New(cfg, opts)
+				Expect(err).NotTo(HaveOccurred())
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				go func() {
+					defer GinkgoRecover()
+					Expect(m.Start(ctx)).NotTo(HaveOccurred())
+				}()
+
+				metricsEndpoint := fmt.Sprintf("http://%s/metrics", listener.Addr().String())
+				resp, err := http.Get(metricsEndpoint)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("should close the metrics listener if the metrics filter fails", func() {
+				opts.MetricsBindAddress = ":0"
+				opts.MetricsFilterProvider = func(_ *rest.Config) (metrics.Filter, error) {
+					return func(_ logr.Logger, _ http.Handler) (http.Handler, error) {
+						return nil, fmt.Errorf("expected error")
+					}, nil
+				}
+				m, err := New(cfg, opts)
+				Expect(err).NotTo(HaveOccurred())
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				Expect(m.Start(ctx)).To(MatchError(ContainSubstring("unable to apply metrics filter: expected error")))
+
+				_, err = listener.Accept()
+				Expect(err).To(MatchError(net.ErrClosed))
+			})
+
+			It("should return an error if the metrics filter provider fails", func() {
+				opts.MetricsBindAddress = ":0"
+				opts.MetricsFilterProvider = func(_ *rest.Config) (metrics.Filter, error) {
+					return nil, fmt.Errorf("expected error")
+				}
+				_, err := New(cfg, opts)
+				Expect(err).To(MatchError(ContainSubstring("expected error")))
+			})
 		})
 	})
 
@@ -1401,6 +1588,239 @@ var _ = Describe("manger.Manager", func() {
 			Expect(m.Add(&failRec{})).To(HaveOccurred())
 		})
 	})
+	Describe("leader election timings", func() {
+		durationPtr := func(d time.Duration) *time.Duration { return &d }
+
+		It("should return an error if LeaseDuration isn't greater than RenewDeadline", func() {
+			_, err := New(cfg, Options{
+				LeaderElection:   true,
+				LeaderElectionID: "test-leader-election-id",
+				LeaseDuration:    durationPtr(10 * time.Second),
+				RenewDeadline:    durationPtr(10 * time.Second),
+			})
+			Expect(err).To(MatchError("leader election LeaseDuration (10s) must be greater than RenewDeadline (10s)"))
+		})
+
+		It("should return an error if RenewDeadline isn't greater than the jittered RetryPeriod", func() {
+			_, err := New(cfg, Options{
+				LeaderElection:   true,
+				LeaderElectionID: "test-leader-election-id",
+				RenewDeadline:    durationPtr(2 * time.Second),
+				RetryPeriod:      durationPtr(2 * time.Second),
+			})
+			Expect(err).To(MatchError(ContainSubstring("RenewDeadline (2s) must be greater than 1.2 times RetryPeriod (2s)")))
+		})
+
+		It("should not validate the timings if leader election is disabled", func() {
+			_, err := New(cfg, Options{
+				LeaseDuration: durationPtr(time.Second),
+				RenewDeadline: durationPtr(2 * time.Second),
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("UserAgent", func() {
+		It("should set the User-Agent on a copy of the config", func() {
+			m, err := New(cfg, Options{UserAgent: "my-operator/v1.2.3"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.GetConfig().UserAgent).To(Equal("my-operator/v1.2.3"))
+			Expect(cfg.UserAgent).To(BeEmpty())
+		})
+
+		It("should set the User-Agent on the leader election config if it has none", func() {
+			var leaderConfig *rest.Config
+			leaderElectionConfig := rest.CopyConfig(cfg)
+			_, err := New(cfg, Options{
+				UserAgent:            "my-operator/v1.2.3",
+				LeaderElectionConfig: leaderElectionConfig,
+				newResourceLock: func(config *rest.Config, recorderProvider recorder.Provider, options leaderelection.Options) (resourcelock.Interface, error) {
+					leaderConfig = config
+					return nil, nil
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leaderConfig.UserAgent).To(Equal("my-operator/v1.2.3"))
+			Expect(leaderElectionConfig.UserAgent).To(BeEmpty())
+		})
+
+		It("should append to the User-Agent in the requests of the leader election lock", func() {
+			userAgents := make(chan string, 10)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				userAgents <- r.UserAgent()
+				http.NotFound(w, r)
+			}))
+			defer server.Close()
+
+			m, err := New(cfg, Options{
+				UserAgent:               "my-operator/v1.2.3",
+				LeaderElection:          true,
+				LeaderElectionID:        "test-leader-election-id",
+				LeaderElectionNamespace: "default",
+				LeaderElectionConfig:    &rest.Config{Host: server.URL},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, _ = m.(*controllerManager).resourceLock.Get(context.Background())
+			Eventually(userAgents).Should(Receive(Equal("my-operator/v1.2.3/leader-election")))
+		})
+	})
+
+	Describe("AddCluster", func() {
+		It("should make the cluster available by name", func() {
+			m, err := New(cfg, Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			spoke, err := cluster.New(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.AddCluster("spoke-1", spoke)).To(Succeed())
+
+			got, err := m.GetCluster("spoke-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got).To(BeIdenticalTo(spoke))
+
+			_, err = m.GetCluster("spoke-2")
+			Expect(err).To(MatchError(`no cluster registered with name "spoke-2"`))
+		})
+
+		It("should reject duplicate and empty names", func() {
+			m, err := New(cfg, Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			spoke, err := cluster.New(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(m.AddCluster("spoke-1", spoke)).To(Succeed())
+			Expect(m.AddCluster("spoke-1", spoke)).To(MatchError(`cluster "spoke-1" is already registered`))
+			Expect(m.AddCluster("", spoke)).To(MatchError("cluster name must not be empty"))
+		})
+
+		It("should inject itself into components that ask for a ClusterGetter", func() {
+			m, err := New(cfg, Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			c := &clusterGetterInjectable{}
+			Expect(m.SetFields(c)).To(Succeed())
+			Expect(c.getter).To(BeIdenticalTo(m))
+		})
+	})
+
+	Describe("ClusterProvider", func() {
+		It("should engage and disengage the clusters of the provider", func() {
+			spokeCache := &startSignalingInformer{Cache: &informertest.FakeInformers{}}
+			spoke, err := cluster.New(cfg, func(o *cluster.Options) {
+				o.NewCache = func(_ *rest.Config, _ cache.Options) (cache.Cache, error) {
+					return spokeCache, nil
+				}
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			provider := &fakeClusterProvider{engaged: make(chan error), disengage: make(chan struct{}), disengaged: make(chan error)}
+			provider.clusters = map[string]cluster.Cluster{"spoke-1": spoke}
+			m, err := New(cfg, Options{ClusterProvider: provider})
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(m.Start(ctx)).To(Succeed())
+			}()
+
+			Expect(<-provider.engaged).To(Succeed())
+			Eventually(spokeCache.started).Should(BeTrue())
+			got, err := m.GetCluster("spoke-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got).To(BeIdenticalTo(spoke))
+
+			close(provider.disengage)
+			Expect(<-provider.disengaged).To(Succeed())
+			_, err = m.GetCluster("spoke-1")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should wait for a disengaged cluster to stop", func() {
+			spoke, err := cluster.New(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			slowSpoke := &slowStoppingCluster{Cluster: spoke}
+
+			provider := &fakeClusterProvider{engaged: make(chan error), disengage: make(chan struct{}), disengaged: make(chan error)}
+			provider.clusters = map[string]cluster.Cluster{"spoke-1": slowSpoke}
+			m, err := New(cfg, Options{ClusterProvider: provider})
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer GinkgoRecover()
+				Expect(m.Start(ctx)).To(Succeed())
+			}()
+
+			Expect(<-provider.engaged).To(Succeed())
+			close(provider.disengage)
+			Expect(<-provider.disengaged).To(Succeed())
+			Expect(slowSpoke.isStopped()).To(BeTrue())
+		})
+
+		It("should not engage clusters before the manager is started", func() {
+			spoke, err := cluster.New(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			m, err := New(cfg, Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			cm, ok := m.(*controllerManager)
+			Expect(ok).To(BeTrue())
+			Expect(cm.Engage("spoke-1", spoke)).To(MatchError("can't engage a cluster before the manager is started"))
+			_, err = m.GetCluster("spoke-1")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should not engage clusters once the manager is stopping", func() {
+			spoke, err := cluster.New(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			m, err := New(cfg, Options{})
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(stopped)
+				Expect(m.Start(ctx)).To(Succeed())
+			}()
+			Eventually(m.Elected()).Should(BeClosed())
+			cancel()
+			<-stopped
+
+			cm, ok := m.(*controllerManager)
+			Expect(ok).To(BeTrue())
+			Expect(cm.Engage("spoke-1", spoke)).To(MatchError("can't engage a cluster as stop procedure is already engaged"))
+		})
+
+		It("should not block clusters engaged while the manager is stopping", func() {
+			spoke, err := cluster.New(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			provider := &engageOnStopProvider{cluster: spoke, engaged: make(chan error, 1)}
+			m, err := New(cfg, Options{ClusterProvider: provider})
+			Expect(err).NotTo(HaveOccurred())
+			provider.cm = m.(*controllerManager)
+			// Wait for the runnables without a timeout.
+			provider.cm.gracefulShutdownTimeout = -1
+
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				defer close(stopped)
+				Expect(m.Start(ctx)).To(Succeed())
+			}()
+			Eventually(m.Elected()).Should(BeClosed())
+			cancel()
+
+			Eventually(provider.engaged).Should(Receive(MatchError("can't engage a cluster as stop procedure is already engaged")))
+			Eventually(stopped).Should(BeClosed())
+		})
+	})
+
 	Describe("SetFields", func() {
 		It("should inject field values", func() {
 			m, err := New(cfg, Options{
@@ -1758,3 +2178,71 @@ func (c *startSignalingInformer) WaitForCacheSync(ctx context.Context) bool {
 	}()
 	return c.Cache.WaitForCacheSync(ctx)
 }
+
+type clusterGetterInjectable struct {
+	getter ClusterGetter
+}
+
+func (c *clusterGetterInjectable) InjectClusterGetter(getter ClusterGetter) error {
+	c.getter = getter
+	return nil
+}
+
+// slowStoppingCluster is a cluster that takes a while to stop.
+type slowStoppingCluster struct {
+	cluster.Cluster
+	stopped int32
+}
+
+func (c *slowStoppingCluster) Start(ctx context.Context) error {
+	<-ctx.Done()
+	time.Sleep(100 * time.Millisecond)
+	atomic.StoreInt32(&c.stopped, 1)
+	return nil
+}
+
+func (c *slowStoppingCluster) isStopped() bool {
+	return atomic.LoadInt32(&c.stopped) == 1
+}
+
+// engageOnStopProvider engages a cluster once it is asked to stop and the stop
+// procedure holds the manager lock while it waits for the runnables.
+type engageOnStopProvider struct {
+	cm      *controllerManager
+	cluster cluster.Cluster
+	engaged chan error
+}
+
+func (p *engageOnStopProvider) Run(ctx context.Context, aware cluster.Aware) error {
+	<-ctx.Done()
+	for p.cm.mu.TryLock() {
+		p.cm.mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+	p.engaged <- aware.Engage("spoke-1", p.cluster)
+	return nil
+}
+
+// fakeClusterProvider engages its clusters, then disengages them once disengage is closed.
+type fakeClusterProvider struct {
+	clusters   map[string]cluster.Cluster
+	engaged    chan error
+	disengage  chan struct{}
+	disengaged chan error
+}
+
+func (p *fakeClusterProvider) Run(ctx context.Context, aware cluster.Aware) error {
+	for name, cl := range p.clusters {
+		p.engaged <- aware.Engage(name, cl)
+	}
+	select {
+	case <-p.disengage:
+	case <-ctx.Done():
+		return nil
+	}
+	for name := range p.clusters {
+		p.disengaged <- aware.Disengage(name)
+	}
+	<-ctx.Done()
+	return nil
+}
@@ -34,6 +34,7 @@ import (
 const (
 	RestClientSubsystem = "rest_client"
 	LatencyKey          = "request_latency_seconds"
+	DurationKey         = "request_duration_seconds"
 	ResultKey           = "requests_total"
 )
 
@@ -76,6 +77,15 @@ var (
 		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 10),
 	}, []string{"verb", "url"})
 
+	// requestDuration reports the request latency in seconds per verb and
+	// host, which unlike RequestLatency keeps the cardinality bounded.
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: RestClientSubsystem,
+		Name:      DurationKey,
+		Help:      "Request latency in seconds. Broken down by verb and host.",
+		Buckets:   []float64{0.005, 0.025, 0.1, 0.25, 0.5, 1.0, 2.0, 4.0, 8.0, 15.0, 30.0, 60.0},
+	}, []string{"verb", "host"})
+
 	requestResult = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Subsystem: RestClientSubsystem,
 		Name:      ResultKey,
@@ -144,11 +154,13 @@ func init() {
 // registerClientMetrics sets up the client latency metrics from client-go.
 func registerClientMetrics() {
 	// register the metrics with our registry
+	Registry.MustRegister(requestDuration)
 	Registry.MustRegister(requestResult)
 
 	// register the metrics with client-go
 	clientmetrics.Register(clientmetrics.RegisterOpts{
-		RequestResult: &resultAdapter{metric: requestResult},
+		RequestLatency: &hostLatencyAdapter{metric: requestDuration},
+		RequestResult:  &resultAdapter{metric: requestResult},
 	})
 }
 
@@ -184,6 +196,14 @@ func (l *LatencyAdapter) Observe(_ context.Context, verb string, u url.URL, late
 	l.metric.WithLabelValues(verb, u.String()).Observe(latency.Seconds())
 }
 
+type hostLatencyAdapter struct {
+	metric *prometheus.HistogramVec
+}
+
+func (l *hostLatencyAdapter) Observe(_ context.Context, verb string, u url.URL, latency time.Duration) {
+	l.metric.WithLabelValues(verb, u.Host).Observe(latency.Seconds())
+}
+
 type resultAdapter struct {
 	metric *prometheus.CounterVec
 }
@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+var _ = Describe("Client metrics", func() {
+	It("should record the latency and result of requests by host", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"major": "1", "minor": "22"}`))
+		}))
+		defer server.Close()
+		serverURL, err := url.Parse(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+
+		cs, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cs.Discovery().ServerVersion()
+		Expect(err).NotTo(HaveOccurred())
+
+		var duration dto.Metric
+		Expect(requestDuration.WithLabelValues("GET", serverURL.Host).(prometheus.Histogram).Write(&duration)).To(Succeed())
+		Expect(duration.GetHistogram().GetSampleCount()).To(Equal(uint64(1)))
+		Expect(testutil.ToFloat64(requestResult.WithLabelValues("200", "GET", serverURL.Host))).To(Equal(1.0))
+	})
+})
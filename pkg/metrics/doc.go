@@ -15,6 +15,21 @@ limitations under the License.
 */
 
 /*
-Package metrics contains controller related metrics utilities
+Package metrics contains controller related metrics utilities.
+
+Registry is the Prometheus registry shared by all of controller-runtime:
+controllers, workqueues, clients, leader election and webhooks register
+their metrics into it, and the manager serves it on its metrics endpoint.
+Custom metrics are published by registering them into the same registry,
+usually from an init function:
+
+	var reconciledWidgets = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "widgets_reconciled_total",
+		Help: "Number of widgets reconciled",
+	})
+
+	func init() {
+		metrics.Registry.MustRegister(reconciledWidgets)
+	}
 */
 package metrics
@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics_test
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// This example registers a custom counter in the shared registry, so that it's
+// served on the manager's metrics endpoint alongside controller-runtime's own
+// metrics.
+func Example() {
+	reconciledWidgets := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "widgets_reconciled_total",
+		Help: "Number of widgets reconciled",
+	})
+	metrics.Registry.MustRegister(reconciledWidgets)
+	defer metrics.Registry.Unregister(reconciledWidgets)
+
+	reconciledWidgets.Inc()
+
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		panic(err)
+	}
+	for _, family := range families {
+		if family.GetName() == "widgets_reconciled_total" {
+			fmt.Println(family.GetName(), family.GetMetric()[0].GetCounter().GetValue())
+		}
+	}
+	// Output: widgets_reconciled_total 1
+}
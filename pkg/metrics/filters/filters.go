@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package filters contains filters that can be used to secure the metrics
+// endpoint of the manager.
+package filters
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// WithAuthenticationAndAuthorization provides a metrics.Filter that
+// authenticates requests with their bearer token through a TokenReview, and
+// authorizes the authenticated user through a SubjectAccessReview for the
+// request path and verb, as for any non-resource URL of the API server.
+//
+// Requests without a valid token are rejected with 401 Unauthorized, and
+// requests of users that aren't allowed to access the path with 403 Forbidden.
+// Every request results in a TokenReview and a SubjectAccessReview; the
+// results are not cached.
+//
+// The manager needs permission to create TokenReviews and
+// SubjectAccessReviews, and Prometheus needs to be granted access to the
+// metrics path, e.g. with a ClusterRole rule for the "/metrics" non-resource
+// URL and the "get" verb.
+func WithAuthenticationAndAuthorization(config *rest.Config) (metrics.Filter, error) {
+	cs, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create client for metrics authentication and authorization: %w", err)
+	}
+
+	return func(log logr.Logger, handler http.Handler) (http.Handler, error) {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx := req.Context()
+
+			token := bearerToken(req)
+			if token == "" {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			tokenReview, err := cs.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+				Spec: authenticationv1.TokenReviewSpec{Token: token},
+			}, metav1.CreateOptions{})
+			if err != nil {
+				log.Error(err, "unable to authenticate metrics request")
+				http.Error(w, "Authentication failed", http.StatusInternalServerError)
+				return
+			}
+			if !tokenReview.Status.Authenticated {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			user := tokenReview.Status.User
+			extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+			for k, v := range user.Extra {
+				extra[k] = authorizationv1.ExtraValue(v)
+			}
+			sar, err := cs.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					User:   user.Username,
+					UID:    user.UID,
+					Groups: user.Groups,
+					Extra:  extra,
+					NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+						Path: req.URL.Path,
+						Verb: strings.ToLower(req.Method),
+					},
+				},
+			}, metav1.CreateOptions{})
+			if err != nil {
+				log.Error(err, "unable to authorize metrics request", "user", user.Username)
+				http.Error(w, "Authorization failed", http.StatusInternalServerError)
+				return
+			}
+			if !sar.Status.Allowed {
+				log.V(4).Info("metrics request denied", "user", user.Username, "path", req.URL.Path, "reason", sar.Status.Reason)
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+
+			handler.ServeHTTP(w, req)
+		}), nil
+	}, nil
+}
+
+// bearerToken returns the bearer token of the request, if any.
+func bearerToken(req *http.Request) string {
+	auth := strings.TrimSpace(req.Header.Get("Authorization"))
+	parts := strings.SplitN(auth, " ", 2)
+	if len(parts) < 2 || !strings.EqualFold(parts[0], "bearer") {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/rest"
+)
+
+// fakeAPIServer answers TokenReviews and SubjectAccessReviews: the token
+// "valid" belongs to the user "prometheus", which may only get /metrics.
+func fakeAPIServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		defer GinkgoRecover()
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/tokenreviews"):
+			review := &authenticationv1.TokenReview{}
+			Expect(json.NewDecoder(req.Body).Decode(review)).To(Succeed())
+			if review.Spec.Token == "valid" {
+				review.Status.Authenticated = true
+				review.Status.User = authenticationv1.UserInfo{Username: "prometheus"}
+			}
+			Expect(json.NewEncoder(w).Encode(review)).To(Succeed())
+		case strings.HasSuffix(req.URL.Path, "/subjectaccessreviews"):
+			review := &authorizationv1.SubjectAccessReview{}
+			Expect(json.NewDecoder(req.Body).Decode(review)).To(Succeed())
+			attrs := review.Spec.NonResourceAttributes
+			review.Status.Allowed = review.Spec.User == "prometheus" && attrs != nil && attrs.Path == "/metrics" && attrs.Verb == "get"
+			Expect(json.NewEncoder(w).Encode(review)).To(Succeed())
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+}
+
+var _ = Describe("WithAuthenticationAndAuthorization", func() {
+	var (
+		apiServer *httptest.Server
+		handler   http.Handler
+	)
+
+	BeforeEach(func() {
+		apiServer = fakeAPIServer()
+
+		filter, err := WithAuthenticationAndAuthorization(&rest.Config{Host: apiServer.URL})
+		Expect(err).NotTo(HaveOccurred())
+		handler, err = filter(logr.Discard(), http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("metrics"))
+		}))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+	})
+
+	serve := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	It("should serve requests of authorized users", func() {
+		w := serve("/metrics", "valid")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Body.String()).To(Equal("metrics"))
+	})
+
+	It("should reject requests without a token", func() {
+		Expect(serve("/metrics", "").Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should reject requests with an invalid token", func() {
+		Expect(serve("/metrics", "invalid").Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should reject requests of users that aren't authorized for the path", func() {
+		Expect(serve("/debug", "valid").Code).To(Equal(http.StatusForbidden))
+	})
+})
@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/tools/leaderelection"
+)
+
+// Metrics subsystem and all keys used by the leader election.
+const (
+	LeaderElectionSubsystem = "leader_election"
+	LeaderStatusKey         = "master_status"
+	LeaderTransitionsKey    = "master_transitions_total"
+)
+
+var (
+	leaderStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: LeaderElectionSubsystem,
+		Name:      LeaderStatusKey,
+		Help:      "Gauge of if the reporting system is master of the relevant lease, 0 indicates backup, 1 indicates master. 'name' is the string used to identify the lease.",
+	}, []string{"name"})
+
+	leaderTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: LeaderElectionSubsystem,
+		Name:      LeaderTransitionsKey,
+		Help:      "Total number of times the reporting system became or stopped being master of the relevant lease.",
+	}, []string{"name"})
+)
+
+func init() {
+	Registry.MustRegister(leaderStatus)
+	Registry.MustRegister(leaderTransitions)
+
+	leaderelection.SetProvider(leaderelectionMetricsProvider{})
+}
+
+// leaderelectionMetricsProvider provides the leader metric of the leader
+// electors created by client-go.
+type leaderelectionMetricsProvider struct{}
+
+func (leaderelectionMetricsProvider) NewLeaderMetric() leaderelection.SwitchMetric {
+	return &switchAdapter{leading: map[string]bool{}}
+}
+
+// switchAdapter reports the leader status of each lease and counts how often
+// it changes.
+type switchAdapter struct {
+	mu      sync.Mutex
+	leading map[string]bool
+}
+
+func (s *switchAdapter) On(name string) {
+	s.set(name, true)
+}
+
+func (s *switchAdapter) Off(name string) {
+	s.set(name, false)
+}
+
+func (s *switchAdapter) set(name string, leading bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if leading {
+		leaderStatus.WithLabelValues(name).Set(1.0)
+	} else {
+		leaderStatus.WithLabelValues(name).Set(0.0)
+	}
+
+	// Only count actual changes, client-go reports the initial (non-leading)
+	// state of every elector as well.
+	if s.leading[name] != leading {
+		leaderTransitions.WithLabelValues(name).Inc()
+	}
+	s.leading[name] = leading
+}
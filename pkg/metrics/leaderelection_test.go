@@ -0,0 +1,44 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Leader election metrics", func() {
+	It("should report the leader status and count transitions", func() {
+		m := leaderelectionMetricsProvider{}.NewLeaderMetric()
+
+		m.Off("test-lease")
+		Expect(testutil.ToFloat64(leaderStatus.WithLabelValues("test-lease"))).To(Equal(0.0))
+		Expect(testutil.ToFloat64(leaderTransitions.WithLabelValues("test-lease"))).To(Equal(0.0))
+
+		m.On("test-lease")
+		Expect(testutil.ToFloat64(leaderStatus.WithLabelValues("test-lease"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(leaderTransitions.WithLabelValues("test-lease"))).To(Equal(1.0))
+
+		m.On("test-lease")
+		Expect(testutil.ToFloat64(leaderTransitions.WithLabelValues("test-lease"))).To(Equal(1.0))
+
+		m.Off("test-lease")
+		Expect(testutil.ToFloat64(leaderStatus.WithLabelValues("test-lease"))).To(Equal(0.0))
+		Expect(testutil.ToFloat64(leaderTransitions.WithLabelValues("test-lease"))).To(Equal(2.0))
+	})
+})
@@ -96,35 +96,47 @@ func init() {
 	Registry.MustRegister(longestRunningProcessor)
 	Registry.MustRegister(retries)
 
-	workqueue.SetProvider(workqueueMetricsProvider{})
+	workqueue.SetProvider(WorkqueueMetricsProvider{})
 }
 
-type workqueueMetricsProvider struct{}
+// WorkqueueMetricsProvider provides the metrics of the named workqueues, labeled
+// by the name of the queue.  The queues of controllers are named after their
+// controller.  It is registered as the metrics provider of the client-go
+// workqueues, and can be used by other workqueue implementations to export the
+// same metrics.
+type WorkqueueMetricsProvider struct{}
 
-func (workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+// NewDepthMetric implements workqueue.MetricsProvider.
+func (WorkqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
 	return depth.WithLabelValues(name)
 }
 
-func (workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+// NewAddsMetric implements workqueue.MetricsProvider.
+func (WorkqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
 	return adds.WithLabelValues(name)
 }
 
-func (workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+// NewLatencyMetric implements workqueue.MetricsProvider.
+func (WorkqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
 	return latency.WithLabelValues(name)
 }
 
-func (workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+// NewWorkDurationMetric implements workqueue.MetricsProvider.
+func (WorkqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
 	return workDuration.WithLabelValues(name)
 }
 
-func (workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+// NewUnfinishedWorkSecondsMetric implements workqueue.MetricsProvider.
+func (WorkqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
 	return unfinished.WithLabelValues(name)
 }
 
-func (workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+// NewLongestRunningProcessorSecondsMetric implements workqueue.MetricsProvider.
+func (WorkqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
 	return longestRunningProcessor.WithLabelValues(name)
 }
 
-func (workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+// NewRetriesMetric implements workqueue.MetricsProvider.
+func (WorkqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
 	return retries.WithLabelValues(name)
 }
@@ -0,0 +1,39 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/client-go/util/workqueue"
+)
+
+var _ = Describe("Workqueue metrics", func() {
+	It("should export the metrics of named queues labeled by name", func() {
+		q := workqueue.NewNamed("test-controller")
+		defer q.ShutDown()
+
+		q.Add("item")
+		Expect(testutil.ToFloat64(depth.WithLabelValues("test-controller"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(adds.WithLabelValues("test-controller"))).To(Equal(1.0))
+
+		item, _ := q.Get()
+		q.Done(item)
+		Expect(testutil.ToFloat64(depth.WithLabelValues("test-controller"))).To(Equal(0.0))
+	})
+})
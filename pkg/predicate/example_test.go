@@ -17,6 +17,7 @@ limitations under the License.
 package predicate_test
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
@@ -31,3 +32,31 @@ func ExampleFuncs() {
 		},
 	}
 }
+
+// This example creates a Predicate for a spec-driven controller that skips the status-only updates
+// of the objects it reconciles, but still reacts to changes of their labels.
+func ExampleGenerationChangedPredicate() {
+	p = predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})
+}
+
+// This example creates a Predicate that skips the update events of informer resyncs, for a controller
+// that schedules its periodic work with RequeueAfter instead.
+func ExampleResourceVersionChangedPredicate() {
+	p = predicate.ResourceVersionChangedPredicate{}
+}
+
+// This example creates a Predicate that only admits events for objects that are managed by an operator,
+// i.e. that have the app.kubernetes.io/managed-by label set to one of the given values.
+func ExampleLabelSelectorPredicate() {
+	var err error
+	p, err = predicate.LabelSelectorPredicate(metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      "app.kubernetes.io/managed-by",
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{"my-operator", "my-operator-legacy"},
+		}},
+	})
+	if err != nil {
+		// handle it
+	}
+}
@@ -49,6 +49,8 @@ var _ Predicate = GenerationChangedPredicate{}
 var _ Predicate = AnnotationChangedPredicate{}
 var _ Predicate = or{}
 var _ Predicate = and{}
+var _ Predicate = not{}
+var _ Predicate = TypedFuncs[client.Object]{}
 
 // Funcs is a function that implements Predicate.
 type Funcs struct {
@@ -97,6 +99,75 @@ func (p Funcs) Generic(e event.GenericEvent) bool {
 	return true
 }
 
+// TypedFuncs is a variant of Funcs whose functions receive events with objects of the concrete type T,
+// e.g. *corev1.Pod, instead of client.Object. Events with objects that are not of type T are filtered out.
+type TypedFuncs[T client.Object] struct {
+	// Create returns true if the Create event should be processed
+	CreateFunc func(event.TypedCreateEvent[T]) bool
+
+	// Delete returns true if the Delete event should be processed
+	DeleteFunc func(event.TypedDeleteEvent[T]) bool
+
+	// Update returns true if the Update event should be processed
+	UpdateFunc func(event.TypedUpdateEvent[T]) bool
+
+	// Generic returns true if the Generic event should be processed
+	GenericFunc func(event.TypedGenericEvent[T]) bool
+}
+
+// Create implements Predicate.
+func (p TypedFuncs[T]) Create(e event.CreateEvent) bool {
+	obj, ok := e.Object.(T)
+	if !ok {
+		return false
+	}
+	if p.CreateFunc != nil {
+		return p.CreateFunc(event.TypedCreateEvent[T]{Object: obj, IsInInitialList: e.IsInInitialList})
+	}
+	return true
+}
+
+// Delete implements Predicate.
+func (p TypedFuncs[T]) Delete(e event.DeleteEvent) bool {
+	obj, ok := e.Object.(T)
+	if !ok {
+		return false
+	}
+	if p.DeleteFunc != nil {
+		return p.DeleteFunc(event.TypedDeleteEvent[T]{Object: obj, DeleteStateUnknown: e.DeleteStateUnknown})
+	}
+	return true
+}
+
+// Update implements Predicate.
+func (p TypedFuncs[T]) Update(e event.UpdateEvent) bool {
+	objOld, okOld := e.ObjectOld.(T)
+	objNew, okNew := e.ObjectNew.(T)
+	if !okOld || !okNew {
+		return false
+	}
+	if p.UpdateFunc != nil {
+		return p.UpdateFunc(event.TypedUpdateEvent[T]{ObjectOld: objOld, ObjectNew: objNew})
+	}
+	return true
+}
+
+// Generic implements Predicate.  Events for external resources are admitted, as
+// their objects are never of type T.
+func (p TypedFuncs[T]) Generic(e event.GenericEvent) bool {
+	if e.External {
+		return true
+	}
+	obj, ok := e.Object.(T)
+	if !ok {
+		return false
+	}
+	if p.GenericFunc != nil {
+		return p.GenericFunc(event.TypedGenericEvent[T]{Object: obj, Metadata: e.Metadata})
+	}
+	return true
+}
+
 // NewPredicateFuncs returns a predicate funcs that applies the given filter function
 // on CREATE, UPDATE, DELETE and GENERIC events. For UPDATE events, the filter is applied
 // to the new object.
@@ -118,6 +189,10 @@ func NewPredicateFuncs(filter func(object client.Object) bool) Funcs {
 }
 
 // ResourceVersionChangedPredicate implements a default update predicate function on resource version change.
+//
+// This predicate will skip update events that have no change in the object's metadata.resourceVersion field,
+// which are the update events emitted for every object on periodic resyncs of the informers.  Controllers that
+// schedule their periodic work with reconcile.Result.RequeueAfter can use it to avoid redundant reconciles.
 type ResourceVersionChangedPredicate struct {
 	Funcs
 }
@@ -152,6 +227,8 @@ func (ResourceVersionChangedPredicate) Update(e event.UpdateEvent) bool {
 //
 // * With this predicate, any update events with writes only to the status field will not be reconciled.
 // So in the event that the status block is overwritten or wiped by someone else the controller will not self-correct to restore the correct status.
+//
+// * Some types, e.g. ConfigMaps and Secrets, don't have a metadata.generation field, so this predicate drops all of their update events.
 type GenerationChangedPredicate struct {
 	Funcs
 }
@@ -320,14 +397,48 @@ func (o or) Generic(e event.GenericEvent) bool {
 	return false
 }
 
+// Not returns a predicate that implements a logical NOT of the predicate passed to it.
+func Not(predicate Predicate) Predicate {
+	return not{predicate}
+}
+
+type not struct {
+	predicate Predicate
+}
+
+func (n not) Create(e event.CreateEvent) bool {
+	return !n.predicate.Create(e)
+}
+
+func (n not) Update(e event.UpdateEvent) bool {
+	return !n.predicate.Update(e)
+}
+
+func (n not) Delete(e event.DeleteEvent) bool {
+	return !n.predicate.Delete(e)
+}
+
+func (n not) Generic(e event.GenericEvent) bool {
+	return !n.predicate.Generic(e)
+}
+
 // LabelSelectorPredicate constructs a Predicate from a LabelSelector.
 // Only objects matching the LabelSelector will be admitted.
+//
+// For update events only the new object is matched, so updates that remove an object from the
+// selection, e.g. by removing a label, are dropped. Generic events for external resources have no
+// labels to match, and are always admitted. An error is returned if the LabelSelector is invalid.
 func LabelSelectorPredicate(s metav1.LabelSelector) (Predicate, error) {
 	selector, err := metav1.LabelSelectorAsSelector(&s)
 	if err != nil {
 		return Funcs{}, err
 	}
-	return NewPredicateFuncs(func(o client.Object) bool {
+	p := NewPredicateFuncs(func(o client.Object) bool {
 		return selector.Matches(labels.Set(o.GetLabels()))
-	}), nil
+	})
+	genericFunc := p.GenericFunc
+	p.GenericFunc = func(e event.GenericEvent) bool {
+		return e.External || genericFunc(e)
+	}
+	return p, nil
 }
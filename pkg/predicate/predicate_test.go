@@ -159,6 +159,58 @@ var _ = Describe("Predicate", func() {
 		})
 	})
 
+	Describe("TypedFuncs", func() {
+		It("should pass events with the concrete type to its functions", func() {
+			newPod := pod.DeepCopy()
+			newPod.Labels = map[string]string{"foo": "bar"}
+			instance := predicate.TypedFuncs[*corev1.Pod]{
+				CreateFunc: func(evt event.TypedCreateEvent[*corev1.Pod]) bool {
+					return evt.Object.Spec.NodeName == ""
+				},
+				DeleteFunc: func(evt event.TypedDeleteEvent[*corev1.Pod]) bool {
+					return evt.DeleteStateUnknown
+				},
+				UpdateFunc: func(evt event.TypedUpdateEvent[*corev1.Pod]) bool {
+					return evt.ObjectOld.Labels["foo"] != evt.ObjectNew.Labels["foo"]
+				},
+				GenericFunc: func(evt event.TypedGenericEvent[*corev1.Pod]) bool {
+					return evt.Object.Name == "baz"
+				},
+			}
+
+			Expect(instance.Create(event.CreateEvent{Object: pod})).To(BeTrue())
+			Expect(instance.Delete(event.DeleteEvent{Object: pod})).To(BeFalse())
+			Expect(instance.Delete(event.DeleteEvent{Object: pod, DeleteStateUnknown: true})).To(BeTrue())
+			Expect(instance.Update(event.UpdateEvent{ObjectOld: pod, ObjectNew: newPod})).To(BeTrue())
+			Expect(instance.Update(event.UpdateEvent{ObjectOld: pod, ObjectNew: pod})).To(BeFalse())
+			Expect(instance.Generic(event.GenericEvent{Object: pod})).To(BeTrue())
+		})
+
+		It("should return true if the functions are nil", func() {
+			instance := predicate.TypedFuncs[*corev1.Pod]{}
+			Expect(instance.Create(event.CreateEvent{Object: pod})).To(BeTrue())
+			Expect(instance.Delete(event.DeleteEvent{Object: pod})).To(BeTrue())
+			Expect(instance.Update(event.UpdateEvent{ObjectOld: pod, ObjectNew: pod})).To(BeTrue())
+			Expect(instance.Generic(event.GenericEvent{Object: pod})).To(BeTrue())
+		})
+
+		It("should filter out events with objects of another type", func() {
+			cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "biz", Name: "baz"}}
+			instance := predicate.TypedFuncs[*corev1.Pod]{}
+			Expect(instance.Create(event.CreateEvent{Object: cm})).To(BeFalse())
+			Expect(instance.Delete(event.DeleteEvent{Object: cm})).To(BeFalse())
+			Expect(instance.Update(event.UpdateEvent{ObjectOld: pod, ObjectNew: cm})).To(BeFalse())
+			Expect(instance.Generic(event.GenericEvent{Object: cm})).To(BeFalse())
+		})
+
+		It("should admit generic events for external resources", func() {
+			instance := predicate.TypedFuncs[*corev1.Pod]{
+				GenericFunc: func(event.TypedGenericEvent[*corev1.Pod]) bool { return false },
+			}
+			Expect(instance.Generic(event.NewExternalGenericEvent("biz", "baz", nil))).To(BeTrue())
+		})
+	})
+
 	Describe("When checking a ResourceVersionChangedPredicate", func() {
 		instance := predicate.ResourceVersionChangedPredicate{}
 
@@ -859,6 +911,22 @@ var _ = Describe("Predicate", func() {
 				Expect(o.Generic(event.GenericEvent{})).To(BeFalse())
 			})
 		})
+		Describe("When checking a Not predicate", func() {
+			It("should return false when its predicate returns true", func() {
+				n := predicate.Not(passFuncs)
+				Expect(n.Create(event.CreateEvent{})).To(BeFalse())
+				Expect(n.Update(event.UpdateEvent{})).To(BeFalse())
+				Expect(n.Delete(event.DeleteEvent{})).To(BeFalse())
+				Expect(n.Generic(event.GenericEvent{})).To(BeFalse())
+			})
+			It("should return true when its predicate returns false", func() {
+				n := predicate.Not(failFuncs)
+				Expect(n.Create(event.CreateEvent{})).To(BeTrue())
+				Expect(n.Update(event.UpdateEvent{})).To(BeTrue())
+				Expect(n.Delete(event.DeleteEvent{})).To(BeTrue())
+				Expect(n.Generic(event.GenericEvent{})).To(BeTrue())
+			})
+		})
 	})
 
 	Describe("NewPredicateFuncs with a namespace filter function", func() {
@@ -927,6 +995,12 @@ var _ = Describe("Predicate", func() {
 			})
 		})
 
+		Context("When the event is for an external resource", func() {
+			It("should return true", func() {
+				Expect(instance.Generic(event.NewExternalGenericEvent("biz", "baz", nil))).To(BeTrue())
+			})
+		})
+
 		Context("When the Selector matches the event labels", func() {
 			It("should return true", func() {
 				successMatch := &corev1.Pod{
@@ -940,5 +1014,35 @@ var _ = Describe("Predicate", func() {
 				Expect(instance.Update(event.UpdateEvent{ObjectNew: successMatch})).To(BeTrue())
 			})
 		})
+
+		Context("When the Selector has match expressions", func() {
+			It("should only admit objects matching the expressions", func() {
+				instance, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "foo",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"bar", "baz"},
+					}},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				match := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"foo": "baz"}}}
+				noMatch := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"foo": "qux"}}}
+				Expect(instance.Create(event.CreateEvent{Object: match})).To(BeTrue())
+				Expect(instance.Create(event.CreateEvent{Object: noMatch})).To(BeFalse())
+			})
+		})
+
+		Context("When the Selector is invalid", func() {
+			It("should return an error", func() {
+				_, err := predicate.LabelSelectorPredicate(metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "foo",
+						Operator: "invalid",
+					}},
+				})
+				Expect(err).To(HaveOccurred())
+			})
+		})
 	})
 })
@@ -40,3 +40,54 @@ func ExampleFunc() {
 
 	// Output: Name: test, Namespace: default
 }
+
+// This example wraps a reconcile function with middlewares that log every call and
+// convert panics into errors.
+func ExampleChain() {
+	logging := func(next reconcile.Reconciler) reconcile.Reconciler {
+		return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+			fmt.Printf("Reconciling %s\n", req.NamespacedName)
+			return next.Reconcile(ctx, req)
+		})
+	}
+	recoverPanic := func(next reconcile.Reconciler) reconcile.Reconciler {
+		return reconcile.Func(func(ctx context.Context, req reconcile.Request) (res reconcile.Result, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("panic: %v", r)
+				}
+			}()
+			return next.Reconcile(ctx, req)
+		})
+	}
+
+	r := reconcile.Chain(logging, recoverPanic)(reconcile.Func(func(_ context.Context, o reconcile.Request) (reconcile.Result, error) {
+		panic("not implemented")
+	}))
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test"}})
+	fmt.Println(err)
+
+	// Output: Reconciling default/test
+	// panic: not implemented
+}
+
+// This example implements a reconcile function that schedules the next reconciliation
+// for shortly before a certificate expires, instead of relying on error backoff.
+func ExampleResult_requeueAfter() {
+	notAfter := time.Now().Add(90 * 24 * time.Hour)
+
+	r := reconcile.Func(func(_ context.Context, o reconcile.Request) (reconcile.Result, error) {
+		// Renew the certificate one week before it expires.
+		renewAt := notAfter.Add(-7 * 24 * time.Hour)
+		return reconcile.Result{RequeueAfter: time.Until(renewAt)}, nil
+	})
+
+	res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test"}})
+	if err != nil {
+		fmt.Printf("got error: %v\n", err)
+	}
+	fmt.Printf("Requeue after more than 82 days: %v", res.RequeueAfter > 82*24*time.Hour)
+
+	// Output: Requeue after more than 82 days: true
+}
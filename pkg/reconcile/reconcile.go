@@ -18,9 +18,13 @@ package reconcile
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
 	"time"
 
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Result contains the result of a Reconciler invocation.
@@ -30,6 +34,11 @@ type Result struct {
 
 	// RequeueAfter if greater than 0, tells the Controller to requeue the reconcile key after the Duration.
 	// Implies that Requeue is true, there is no need to set Requeue to true at the same time as RequeueAfter.
+	//
+	// Unlike returning an error or setting Requeue, the key is not requeued with rate limiting, and any
+	// backoff previously tracked for it is reset. This makes RequeueAfter suitable to schedule periodic
+	// work at a specific time, e.g. to renew a certificate before it expires. RequeueAfter is ignored
+	// if the Reconciler also returns an error.
 	RequeueAfter time.Duration
 }
 
@@ -47,6 +56,20 @@ func (r *Result) IsZero() bool {
 type Request struct {
 	// NamespacedName is the name and namespace of the object to reconcile.
 	types.NamespacedName
+
+	// ClusterName is the name of the cluster the object lives in, as registered with
+	// Manager.AddCluster.  It is empty for objects in the manager's own cluster.
+	// See handler.WithClusterName for a way to set it from a watch on a secondary cluster.
+	ClusterName string
+}
+
+// String returns the namespace and name of the object, prefixed with "cluster://" and the
+// name of its cluster if ClusterName is set, e.g. "cluster://spoke-1/default/my-pod".
+func (r Request) String() string {
+	if r.ClusterName == "" {
+		return r.NamespacedName.String()
+	}
+	return "cluster://" + r.ClusterName + string(types.Separator) + r.NamespacedName.String()
 }
 
 /*
@@ -85,11 +108,20 @@ Reconciliation is level-based, meaning action isn't driven off changes in indivi
 driven by actual cluster state read from the apiserver or a local cache.
 For example if responding to a Pod Delete Event, the Request won't contain that a Pod was deleted,
 instead the reconcile function observes this when reading the cluster state and seeing the Pod as missing.
+
+The Controller decides how to retry a failed reconciliation based on the returned error:
+
+	* A plain error is retried with the rate limiter of the Controller, i.e. with exponential backoff.
+	* An error wrapped with RetryAfterError is retried after the given duration.
+	* An error wrapped with TerminalError is not retried.
+
+All errors are logged and recorded in the metrics of the Controller.
 */
 type Reconciler interface {
 	// Reconciler performs a full reconciliation for the object referred to by the Request.
 	// The Controller will requeue the Request to be processed again if an error is non-nil or
-	// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+	// Result.Requeue is true, or after Result.RequeueAfter if it is greater than 0. Otherwise
+	// upon completion it will remove the work from the queue.
 	Reconcile(context.Context, Request) (Result, error)
 }
 
@@ -100,3 +132,126 @@ var _ Reconciler = Func(nil)
 
 // Reconcile implements Reconciler.
 func (r Func) Reconcile(ctx context.Context, o Request) (Result, error) { return r(ctx, o) }
+
+// Middleware wraps a Reconciler to add behavior around each call to Reconcile,
+// e.g. logging, metrics, tracing or panic capture.
+type Middleware func(Reconciler) Reconciler
+
+// Chain returns a Middleware that applies the given middlewares in order, so that
+// the first middleware is the outermost one. Chain(a, b, c)(r) is equivalent to
+// a(b(c(r))), i.e. a call to Reconcile passes through a, b and c before it reaches r.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(r Reconciler) Reconciler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			r = middlewares[i](r)
+		}
+		return r
+	}
+}
+
+// ObjectReconciler is a specialized version of Reconciler that acts on instances of client.Object. Each reconciliation
+// event gets the associated object from Kubernetes before passing it to Reconcile. An ObjectReconciler can be used in
+// Builder.Complete by calling AsReconciler. See Reconciler for more details.
+type ObjectReconciler[object client.Object] interface {
+	Reconcile(context.Context, object) (Result, error)
+}
+
+// AsReconciler creates a Reconciler based on the given ObjectReconciler. The returned Reconciler
+// fetches the object for each Request using the given client and passes it to the ObjectReconciler.
+// Requests for objects that no longer exist are dropped without calling the ObjectReconciler.
+//
+// The client is used for all Requests, so the returned Reconciler only supports objects of
+// a single cluster: Requests with a ClusterName fail with a TerminalError. Use a Reconciler
+// that picks the client by Request.ClusterName for watches on secondary clusters.
+func AsReconciler[object client.Object](client client.Client, rec ObjectReconciler[object]) Reconciler {
+	return &objectReconcilerAdapter[object]{
+		objReconciler: rec,
+		client:        client,
+	}
+}
+
+type objectReconcilerAdapter[object client.Object] struct {
+	objReconciler ObjectReconciler[object]
+	client        client.Client
+}
+
+// Reconcile implements Reconciler.
+func (a *objectReconcilerAdapter[object]) Reconcile(ctx context.Context, req Request) (Result, error) {
+	if req.ClusterName != "" {
+		return Result{}, TerminalError(fmt.Errorf("can't reconcile %s of cluster %q: the ObjectReconciler only supports objects of its client's cluster", req.NamespacedName, req.ClusterName))
+	}
+
+	o := reflect.New(reflect.TypeOf(*new(object)).Elem()).Interface().(object)
+	if err := a.client.Get(ctx, req.NamespacedName, o); err != nil {
+		return Result{}, client.IgnoreNotFound(err)
+	}
+
+	return a.objReconciler.Reconcile(ctx, o)
+}
+
+// TerminalError is an error that will not be retried but still be logged
+// and recorded in metrics. Use it for permanent failures, e.g. an invalid
+// spec, for which retrying would only waste queue capacity.
+func TerminalError(wrapped error) error {
+	return &terminalError{err: wrapped}
+}
+
+type terminalError struct {
+	err error
+}
+
+// Unwrap returns the wrapped error. It returns nil if te.err is nil.
+func (te *terminalError) Unwrap() error {
+	return te.err
+}
+
+func (te *terminalError) Error() string {
+	if te.err == nil {
+		return "nil terminal error"
+	}
+	return "terminal error: " + te.err.Error()
+}
+
+// Is reports whether target is a terminal error, so that
+// errors.Is(err, reconcile.TerminalError(nil)) can be used to
+// check whether an error is terminal.
+func (te *terminalError) Is(target error) bool {
+	tp := &terminalError{}
+	return errors.As(target, &tp)
+}
+
+// RetryAfterError wraps err so that the Controller retries the Request after the
+// given duration instead of using its rate limiter, e.g. when the reconciliation
+// depends on an external system that reports when it is available again. The error
+// is still logged and recorded in metrics. A duration that isn't positive is ignored,
+// i.e. the Request is retried as for a plain error.
+func RetryAfterError(err error, after time.Duration) error {
+	return &retryAfterError{err: err, after: after}
+}
+
+// RetryAfter returns the duration after which the Request should be retried if err
+// or any error it wraps was created with RetryAfterError.
+func RetryAfter(err error) (time.Duration, bool) {
+	var rae *retryAfterError
+	if !errors.As(err, &rae) {
+		return 0, false
+	}
+	return rae.after, true
+}
+
+type retryAfterError struct {
+	err   error
+	after time.Duration
+}
+
+// Unwrap returns the wrapped error. It returns nil if rae.err is nil.
+func (rae *retryAfterError) Unwrap() error {
+	return rae.err
+}
+
+func (rae *retryAfterError) Error() string {
+	if rae.err == nil {
+		return fmt.Sprintf("retry after %s", rae.after)
+	}
+	return fmt.Sprintf("retry after %s: %s", rae.after, rae.err.Error())
+}
@@ -18,12 +18,17 @@ package reconcile_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -49,6 +54,22 @@ var _ = Describe("reconcile", func() {
 		})
 	})
 
+	Describe("Request", func() {
+		It("should print the namespace and name", func() {
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-pod"}}
+			Expect(req.String()).To(Equal("default/my-pod"))
+		})
+
+		It("should print the cluster name if it is set", func() {
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: "default", Name: "my-pod"},
+				ClusterName:    "spoke-1",
+			}
+			Expect(req.String()).To(Equal("cluster://spoke-1/default/my-pod"))
+			Expect(fmt.Sprintf("%v", req)).To(Equal("cluster://spoke-1/default/my-pod"))
+		})
+	})
+
 	Describe("Func", func() {
 		It("should call the function with the request and return a nil error.", func() {
 			request := reconcile.Request{
@@ -89,4 +110,172 @@ var _ = Describe("reconcile", func() {
 			Expect(actualErr).To(Equal(err))
 		})
 	})
+
+	Describe("Chain", func() {
+		It("should apply the middlewares in order", func() {
+			var calls []string
+			middleware := func(name string) reconcile.Middleware {
+				return func(next reconcile.Reconciler) reconcile.Reconciler {
+					return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+						calls = append(calls, name+"-before")
+						res, err := next.Reconcile(ctx, req)
+						calls = append(calls, name+"-after")
+						return res, err
+					})
+				}
+			}
+
+			r := reconcile.Chain(middleware("a"), middleware("b"))(reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				calls = append(calls, "reconcile")
+				return reconcile.Result{Requeue: true}, nil
+			}))
+
+			res, err := r.Reconcile(context.Background(), reconcile.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(reconcile.Result{Requeue: true}))
+			Expect(calls).To(Equal([]string{"a-before", "b-before", "reconcile", "b-after", "a-after"}))
+		})
+
+		It("should return the Reconciler unchanged without middlewares", func() {
+			r := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, fmt.Errorf("expected error")
+			})
+
+			_, err := reconcile.Chain()(r).Reconcile(context.Background(), reconcile.Request{})
+			Expect(err).To(MatchError("expected error"))
+		})
+	})
+
+	Describe("AsReconciler", func() {
+		Context("with an existing object", func() {
+			It("should call the ObjectReconciler with the fetched object", func() {
+				pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+				cl := fake.NewClientBuilder().WithObjects(pod).Build()
+
+				var reconciled *corev1.Pod
+				r := reconcile.AsReconciler[*corev1.Pod](cl, &mockObjectReconciler[*corev1.Pod]{
+					reconcileFunc: func(ctx context.Context, obj *corev1.Pod) (reconcile.Result, error) {
+						reconciled = obj
+						return reconcile.Result{Requeue: true}, nil
+					},
+				})
+
+				res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(reconcile.Result{Requeue: true}))
+				Expect(reconciled).NotTo(BeNil())
+				Expect(reconciled.Name).To(Equal("test"))
+				Expect(reconciled.Namespace).To(Equal("default"))
+			})
+
+			It("should return the error of the ObjectReconciler", func() {
+				pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+				cl := fake.NewClientBuilder().WithObjects(pod).Build()
+
+				r := reconcile.AsReconciler[*corev1.Pod](cl, &mockObjectReconciler[*corev1.Pod]{
+					reconcileFunc: func(ctx context.Context, obj *corev1.Pod) (reconcile.Result, error) {
+						return reconcile.Result{}, fmt.Errorf("expected error")
+					},
+				})
+
+				_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test"}})
+				Expect(err).To(MatchError("expected error"))
+			})
+		})
+
+		Context("with an object that doesn't exist", func() {
+			It("should not call the ObjectReconciler and not return an error", func() {
+				cl := fake.NewClientBuilder().Build()
+
+				called := false
+				r := reconcile.AsReconciler[*corev1.Pod](cl, &mockObjectReconciler[*corev1.Pod]{
+					reconcileFunc: func(ctx context.Context, obj *corev1.Pod) (reconcile.Result, error) {
+						called = true
+						return reconcile.Result{}, nil
+					},
+				})
+
+				res, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.IsZero()).To(BeTrue())
+				Expect(called).To(BeFalse())
+			})
+		})
+
+		Context("with a request for another cluster", func() {
+			It("should not call the ObjectReconciler and return a terminal error", func() {
+				pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+				cl := fake.NewClientBuilder().WithObjects(pod).Build()
+
+				called := false
+				r := reconcile.AsReconciler[*corev1.Pod](cl, &mockObjectReconciler[*corev1.Pod]{
+					reconcileFunc: func(ctx context.Context, obj *corev1.Pod) (reconcile.Result, error) {
+						called = true
+						return reconcile.Result{}, nil
+					},
+				})
+
+				_, err := r.Reconcile(context.Background(), reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: "default", Name: "test"},
+					ClusterName:    "spoke-1",
+				})
+				Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+				Expect(called).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("TerminalError", func() {
+		It("should be identified by errors.Is", func() {
+			err := fmt.Errorf("invalid spec")
+			terminalErr := reconcile.TerminalError(err)
+			Expect(errors.Is(terminalErr, reconcile.TerminalError(nil))).To(BeTrue())
+			Expect(errors.Is(terminalErr, err)).To(BeTrue())
+			Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeFalse())
+		})
+
+		It("should be identified by errors.Is when wrapped", func() {
+			err := fmt.Errorf("failed to reconcile: %w", reconcile.TerminalError(fmt.Errorf("invalid spec")))
+			Expect(errors.Is(err, reconcile.TerminalError(nil))).To(BeTrue())
+		})
+
+		It("should include the wrapped error in the message", func() {
+			Expect(reconcile.TerminalError(fmt.Errorf("invalid spec")).Error()).To(Equal("terminal error: invalid spec"))
+			Expect(reconcile.TerminalError(nil).Error()).To(Equal("nil terminal error"))
+		})
+	})
+
+	Describe("RetryAfterError", func() {
+		It("should return the duration with RetryAfter", func() {
+			err := fmt.Errorf("not ready")
+			retryErr := reconcile.RetryAfterError(err, time.Minute)
+			after, ok := reconcile.RetryAfter(retryErr)
+			Expect(ok).To(BeTrue())
+			Expect(after).To(Equal(time.Minute))
+			Expect(errors.Is(retryErr, err)).To(BeTrue())
+			Expect(retryErr.Error()).To(Equal("retry after 1m0s: not ready"))
+		})
+
+		It("should return the duration with RetryAfter when wrapped", func() {
+			err := fmt.Errorf("failed to reconcile: %w", reconcile.RetryAfterError(fmt.Errorf("not ready"), time.Minute))
+			after, ok := reconcile.RetryAfter(err)
+			Expect(ok).To(BeTrue())
+			Expect(after).To(Equal(time.Minute))
+		})
+
+		It("should not return a duration for other errors", func() {
+			_, ok := reconcile.RetryAfter(fmt.Errorf("not ready"))
+			Expect(ok).To(BeFalse())
+			_, ok = reconcile.RetryAfter(nil)
+			Expect(ok).To(BeFalse())
+		})
+	})
 })
+
+type mockObjectReconciler[T client.Object] struct {
+	reconcileFunc func(context.Context, T) (reconcile.Result, error)
+}
+
+func (r *mockObjectReconciler[T]) Reconcile(ctx context.Context, obj T) (reconcile.Result, error) {
+	return r.reconcileFunc(ctx, obj)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciletest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// RequestFor returns the reconcile.Request for the given object.
+func RequestFor(obj client.Object) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}}
+}
+
+// ReconcileUntilDone calls Reconcile for the Request until it neither returns an error
+// nor asks to be requeued, and returns the last Result. Delays requested via
+// Result.RequeueAfter are not waited for. An error is returned as soon as Reconcile
+// returns one, or if the Reconciler still asks to be requeued after maxIterations calls.
+func ReconcileUntilDone(ctx context.Context, r reconcile.Reconciler, req reconcile.Request, maxIterations int) (reconcile.Result, error) {
+	var res reconcile.Result
+	for i := 0; i < maxIterations; i++ {
+		var err error
+		res, err = r.Reconcile(ctx, req)
+		if err != nil {
+			return res, err
+		}
+		if res.IsZero() {
+			return res, nil
+		}
+	}
+	return res, fmt.Errorf("reconciler still requeues %s after %d iterations", req, maxIterations)
+}
+
+// ResultCheck checks the outcome of a call to Reconcile and returns an error
+// describing the mismatch if it is not the expected one. The result of Reconcile
+// can be passed to it directly, e.g. reconciletest.Done(r.Reconcile(ctx, req)).
+type ResultCheck func(reconcile.Result, error) error
+
+var (
+	_ ResultCheck = Done
+	_ ResultCheck = Requeued
+	_ ResultCheck = Errored
+	_ ResultCheck = TerminalErrored
+)
+
+// Done checks that Reconcile neither returned an error nor asked to be requeued.
+func Done(res reconcile.Result, err error) error {
+	if err != nil {
+		return fmt.Errorf("expected no error, got %w", err)
+	}
+	if !res.IsZero() {
+		return fmt.Errorf("expected no requeue, got %+v", res)
+	}
+	return nil
+}
+
+// Requeued checks that Reconcile did not return an error and asked to be requeued
+// with rate limiting.
+func Requeued(res reconcile.Result, err error) error {
+	if err != nil {
+		return fmt.Errorf("expected no error, got %w", err)
+	}
+	if !res.Requeue || res.RequeueAfter > 0 {
+		return fmt.Errorf("expected Requeue without RequeueAfter, got %+v", res)
+	}
+	return nil
+}
+
+// RequeuedAfter returns a ResultCheck that checks that Reconcile did not return an
+// error and asked to be requeued after the given duration.
+func RequeuedAfter(d time.Duration) ResultCheck {
+	return func(res reconcile.Result, err error) error {
+		if err != nil {
+			return fmt.Errorf("expected no error, got %w", err)
+		}
+		if res.RequeueAfter != d {
+			return fmt.Errorf("expected RequeueAfter %s, got %+v", d, res)
+		}
+		return nil
+	}
+}
+
+// Errored checks that Reconcile returned an error that is retried, i.e. that
+// is not a reconcile.TerminalError.
+func Errored(_ reconcile.Result, err error) error {
+	if err == nil {
+		return errors.New("expected an error, got none")
+	}
+	if errors.Is(err, reconcile.TerminalError(nil)) {
+		return fmt.Errorf("expected a retriable error, got %w", err)
+	}
+	return nil
+}
+
+// TerminalErrored checks that Reconcile returned a reconcile.TerminalError.
+func TerminalErrored(_ reconcile.Result, err error) error {
+	if !errors.Is(err, reconcile.TerminalError(nil)) {
+		return fmt.Errorf("expected a terminal error, got %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciletest_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile/reconciletest"
+)
+
+var _ = Describe("reconciletest", func() {
+	It("RequestFor should return the Request for an object", func() {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"}}
+		Expect(reconciletest.RequestFor(pod)).To(Equal(reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: "default", Name: "test"},
+		}))
+	})
+
+	Describe("ReconcileUntilDone", func() {
+		It("should call Reconcile until it no longer asks for requeue", func() {
+			calls := 0
+			r := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				calls++
+				if calls < 3 {
+					return reconcile.Result{RequeueAfter: time.Hour}, nil
+				}
+				return reconcile.Result{}, nil
+			})
+
+			res, err := reconciletest.ReconcileUntilDone(context.Background(), r, reconcile.Request{}, 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.IsZero()).To(BeTrue())
+			Expect(calls).To(Equal(3))
+		})
+
+		It("should return the error of Reconcile", func() {
+			r := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, fmt.Errorf("expected error")
+			})
+
+			_, err := reconciletest.ReconcileUntilDone(context.Background(), r, reconcile.Request{}, 5)
+			Expect(err).To(MatchError("expected error"))
+		})
+
+		It("should return an error if the Reconciler keeps asking for requeue", func() {
+			r := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{Requeue: true}, nil
+			})
+
+			_, err := reconciletest.ReconcileUntilDone(context.Background(), r, reconcile.Request{}, 5)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ResultChecks", func() {
+		terminalErr := reconcile.TerminalError(fmt.Errorf("invalid spec"))
+		retriableErr := fmt.Errorf("conflict")
+
+		It("Done should only accept a zero Result without error", func() {
+			Expect(reconciletest.Done(reconcile.Result{}, nil)).To(Succeed())
+			Expect(reconciletest.Done(reconcile.Result{Requeue: true}, nil)).NotTo(Succeed())
+			Expect(reconciletest.Done(reconcile.Result{}, retriableErr)).NotTo(Succeed())
+		})
+
+		It("Requeued should only accept Requeue without error", func() {
+			Expect(reconciletest.Requeued(reconcile.Result{Requeue: true}, nil)).To(Succeed())
+			Expect(reconciletest.Requeued(reconcile.Result{}, nil)).NotTo(Succeed())
+			Expect(reconciletest.Requeued(reconcile.Result{RequeueAfter: time.Second}, nil)).NotTo(Succeed())
+			Expect(reconciletest.Requeued(reconcile.Result{Requeue: true}, retriableErr)).NotTo(Succeed())
+		})
+
+		It("RequeuedAfter should only accept the given RequeueAfter without error", func() {
+			Expect(reconciletest.RequeuedAfter(time.Second)(reconcile.Result{RequeueAfter: time.Second}, nil)).To(Succeed())
+			Expect(reconciletest.RequeuedAfter(time.Second)(reconcile.Result{RequeueAfter: time.Minute}, nil)).NotTo(Succeed())
+			Expect(reconciletest.RequeuedAfter(time.Second)(reconcile.Result{RequeueAfter: time.Second}, retriableErr)).NotTo(Succeed())
+		})
+
+		It("Errored should only accept retriable errors", func() {
+			Expect(reconciletest.Errored(reconcile.Result{}, retriableErr)).To(Succeed())
+			Expect(reconciletest.Errored(reconcile.Result{}, terminalErr)).NotTo(Succeed())
+			Expect(reconciletest.Errored(reconcile.Result{}, nil)).NotTo(Succeed())
+		})
+
+		It("TerminalErrored should only accept terminal errors", func() {
+			Expect(reconciletest.TerminalErrored(reconcile.Result{}, terminalErr)).To(Succeed())
+			Expect(reconciletest.TerminalErrored(reconcile.Result{}, retriableErr)).NotTo(Succeed())
+			Expect(reconciletest.TerminalErrored(reconcile.Result{}, nil)).NotTo(Succeed())
+		})
+	})
+})
@@ -29,17 +29,18 @@ limitations under the License.
 //  )
 //
 //  func init() {
-//  	SchemeBuilder.Register(&MyType{}, &MyTypeList)
+//  	SchemeBuilder.Register(&MyType{}, &MyTypeList{})
 //  }
-//  var (
-//  	scheme *runtime.Scheme = runtime.NewScheme()
-//  )
 //
 // This also true of the built-in Kubernetes types.  Then, in the entrypoint for
 // your manager, assemble the scheme containing exactly the types you need,
 // panicing if scheme registration failed. For instance, if our controller needs
 // types from the core/v1 API group (e.g. Pod), plus types from my.api.group/v1:
 //
+//  var (
+//  	scheme *runtime.Scheme = runtime.NewScheme()
+//  )
+//
 //  func init() {
 //  	utilruntime.Must(myapigroupv1.AddToScheme(scheme))
 //  	utilruntime.Must(kubernetesscheme.AddToScheme(scheme))
@@ -55,6 +56,8 @@ limitations under the License.
 package scheme
 
 import (
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -67,8 +70,12 @@ type Builder struct {
 }
 
 // Register adds one or more objects to the SchemeBuilder so they can be added to a Scheme.  Register mutates bld.
+// Adding the objects to a Scheme fails if bld has no GroupVersion.Version.
 func (bld *Builder) Register(object ...runtime.Object) *Builder {
 	bld.SchemeBuilder.Register(func(scheme *runtime.Scheme) error {
+		if bld.GroupVersion.Version == "" {
+			return fmt.Errorf("no version set on the scheme.Builder for group %q", bld.GroupVersion.Group)
+		}
 		scheme.AddKnownTypes(bld.GroupVersion, object...)
 		metav1.AddToGroupVersion(scheme, bld.GroupVersion)
 		return nil
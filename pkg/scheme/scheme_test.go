@@ -63,6 +63,13 @@ var _ = Describe("Scheme", func() {
 			}))
 		})
 
+		It("should return an error if the GroupVersion has no version", func() {
+			_, err := (&scheme.Builder{GroupVersion: schema.GroupVersion{Group: "my.api.group"}}).
+				Register(&corev1.Pod{}).
+				Build()
+			Expect(err).To(MatchError(`no version set on the scheme.Builder for group "my.api.group"`))
+		})
+
 		It("should be able to add types from other Builders", func() {
 			gv1 := schema.GroupVersion{Group: "core", Version: "v1"}
 			b1 := (&scheme.Builder{GroupVersion: gv1}).Register(&corev1.Pod{}, &corev1.PodList{})
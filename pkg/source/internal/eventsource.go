@@ -17,6 +17,7 @@ limitations under the License.
 package internal
 
 import (
+	"context"
 	"fmt"
 
 	"k8s.io/client-go/tools/cache"
@@ -35,14 +36,24 @@ var _ cache.ResourceEventHandler = EventHandler{}
 
 // EventHandler adapts a handler.EventHandler interface to a cache.ResourceEventHandler interface.
 type EventHandler struct {
+	// Ctx is passed to the functions of EventHandler. Defaults to context.Background().
+	Ctx context.Context
+
 	EventHandler handler.EventHandler
 	Queue        workqueue.RateLimitingInterface
 	Predicates   []predicate.Predicate
+
+	// HasSynced reports whether the source has finished its initial sync. Create events
+	// observed before that are flagged as being part of the initial list. May be nil.
+	HasSynced func() bool
 }
 
 // OnAdd creates CreateEvent and calls Create on EventHandler.
 func (e EventHandler) OnAdd(obj interface{}) {
 	c := event.CreateEvent{}
+	if e.HasSynced != nil {
+		c.IsInInitialList = !e.HasSynced()
+	}
 
 	// Pull Object out of the object
 	if o, ok := obj.(client.Object); ok {
@@ -60,7 +71,7 @@ func (e EventHandler) OnAdd(obj interface{}) {
 	}
 
 	// Invoke create handler
-	e.EventHandler.Create(c, e.Queue)
+	e.EventHandler.Create(e.ctx(), c, e.Queue)
 }
 
 // OnUpdate creates UpdateEvent and calls Update on EventHandler.
@@ -91,7 +102,7 @@ func (e EventHandler) OnUpdate(oldObj, newObj interface{}) {
 	}
 
 	// Invoke update handler
-	e.EventHandler.Update(u, e.Queue)
+	e.EventHandler.Update(e.ctx(), u, e.Queue)
 }
 
 // OnDelete creates DeleteEvent and calls Delete on EventHandler.
@@ -134,5 +145,13 @@ func (e EventHandler) OnDelete(obj interface{}) {
 	}
 
 	// Invoke delete handler
-	e.EventHandler.Delete(d, e.Queue)
+	e.EventHandler.Delete(e.ctx(), d, e.Queue)
+}
+
+// ctx returns the context to pass to the functions of EventHandler.
+func (e EventHandler) ctx() context.Context {
+	if e.Ctx == nil {
+		return context.Background()
+	}
+	return e.Ctx
 }
@@ -21,8 +21,12 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/wait"
+	toolscache "k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -49,6 +53,8 @@ const (
 //
 // * Use Channel for events originating outside the cluster (eh.g. GitHub Webhook callback, Polling external urls).
 //
+// * Use Poll for events originating outside the cluster that can only be observed by polling (e.g. a cloud API).
+//
 // Users may build their own Source implementations.  If their implementations implement any of the inject package
 // interfaces, the dependencies will be injected by the Controller when Watch is called.
 type Source interface {
@@ -85,10 +91,17 @@ func (ks *kindWithCache) WaitForSync(ctx context.Context) error {
 }
 
 // Kind is used to provide a source of events originating inside the cluster from Watches (e.g. Pod Create).
+// Use handler.TypedFuncs and predicate.TypedFuncs with the type of Type to receive its events with
+// objects of the concrete type.
 type Kind struct {
 	// Type is the type of object to watch.  e.g. &v1.Pod{}
 	Type client.Object
 
+	// Predicates filter the events of this source before they are passed to the EventHandler.
+	// They are evaluated before the predicates passed to Controller.Watch, so events every
+	// Watch would drop are filtered out before any per-watch work is done.
+	Predicates []predicate.Predicate
+
 	// cache used to watch APIs
 	cache cache.Cache
 
@@ -96,12 +109,17 @@ type Kind struct {
 	// contain an error, startup and syncing finished.
 	started     chan error
 	startCancel func()
+	// startDone is closed once the goroutine of the last call to Start returned.
+	startDone chan struct{}
+	// eventHandler is the event handler registered by the last call to Start.
+	eventHandler *removableEventHandler
 }
 
 var _ SyncingSource = &Kind{}
 
 // Start is internal and should be called only by the Controller to register an EventHandler with the Informer
-// to enqueue reconcile.Requests.
+// to enqueue reconcile.Requests.  It may be called again if WaitForSync failed, which replaces the EventHandler
+// registered before.
 func (ks *Kind) Start(ctx context.Context, handler handler.EventHandler, queue workqueue.RateLimitingInterface,
 	prct ...predicate.Predicate) error {
 	// Type should have been specified by the user.
@@ -114,11 +132,31 @@ func (ks *Kind) Start(ctx context.Context, handler handler.EventHandler, queue w
 		return fmt.Errorf("must call CacheInto on Kind before calling Start")
 	}
 
+	if len(ks.Predicates) > 0 {
+		prct = append(append([]predicate.Predicate{}, ks.Predicates...), prct...)
+	}
+
+	// The Kind is started again if it failed to sync before.  Informers can't remove
+	// event handlers, so stop the previous start and drop the events of its handler,
+	// so that every event is handled only once.
+	if ks.startCancel != nil {
+		ks.startCancel()
+		<-ks.startDone
+		ks.eventHandler.remove()
+	}
+
 	// cache.GetInformer will block until its context is cancelled if the cache was already started and it can not
 	// sync that informer (most commonly due to RBAC issues).
 	ctx, ks.startCancel = context.WithCancel(ctx)
-	ks.started = make(chan error)
+	// started is buffered so that the goroutine can return even if nobody waits for it anymore.
+	started := make(chan error, 1)
+	done := make(chan struct{})
+	var synced int32
+	eventHandler := &removableEventHandler{ResourceEventHandler: internal.EventHandler{Ctx: ctx, Queue: queue,
+		EventHandler: handler, Predicates: prct, HasSynced: func() bool { return atomic.LoadInt32(&synced) == 1 }}}
+	ks.started, ks.startDone, ks.eventHandler = started, done, eventHandler
 	go func() {
+		defer close(done)
 		// Lookup the Informer from the Cache and add an EventHandler which populates the Queue
 		i, err := ks.cache.GetInformer(ctx, ks.Type)
 		if err != nil {
@@ -127,20 +165,70 @@ func (ks *Kind) Start(ctx context.Context, handler handler.EventHandler, queue w
 				log.Error(err, "if kind is a CRD, it should be installed before calling Start",
 					"kind", kindMatchErr.GroupKind)
 			}
-			ks.started <- err
+			started <- fmt.Errorf("failed to get informer for Kind %s: %w", ks.kind(), err)
 			return
 		}
-		i.AddEventHandler(internal.EventHandler{Queue: queue, EventHandler: handler, Predicates: prct})
+		i.AddEventHandler(eventHandler)
 		if !ks.cache.WaitForCacheSync(ctx) {
 			// Would be great to return something more informative here
-			ks.started <- errors.New("cache did not sync")
+			// Most commonly the informer failed to list the objects, e.g. because
+			// RBAC does not allow it, which is logged by the informer.
+			started <- fmt.Errorf("cache did not sync for Kind %s", ks.kind())
+			return
 		}
-		close(ks.started)
+		atomic.StoreInt32(&synced, 1)
+		close(started)
 	}()
 
 	return nil
 }
 
+// removableEventHandler is a ResourceEventHandler that drops all events once it is removed.
+type removableEventHandler struct {
+	toolscache.ResourceEventHandler
+	removed int32
+}
+
+func (h *removableEventHandler) remove() {
+	atomic.StoreInt32(&h.removed, 1)
+}
+
+func (h *removableEventHandler) isRemoved() bool {
+	return atomic.LoadInt32(&h.removed) == 1
+}
+
+// OnAdd implements toolscache.ResourceEventHandler.
+func (h *removableEventHandler) OnAdd(obj interface{}) {
+	if !h.isRemoved() {
+		h.ResourceEventHandler.OnAdd(obj)
+	}
+}
+
+// OnUpdate implements toolscache.ResourceEventHandler.
+func (h *removableEventHandler) OnUpdate(oldObj, newObj interface{}) {
+	if !h.isRemoved() {
+		h.ResourceEventHandler.OnUpdate(oldObj, newObj)
+	}
+}
+
+// OnDelete implements toolscache.ResourceEventHandler.
+func (h *removableEventHandler) OnDelete(obj interface{}) {
+	if !h.isRemoved() {
+		h.ResourceEventHandler.OnDelete(obj)
+	}
+}
+
+// kind returns a description of the type of the Kind for error messages: its
+// GroupVersionKind if set, and its Go type otherwise.
+func (ks *Kind) kind() string {
+	if ks.Type.GetObjectKind() != nil {
+		if gvk := ks.Type.GetObjectKind().GroupVersionKind(); !gvk.Empty() {
+			return gvk.String()
+		}
+	}
+	return fmt.Sprintf("%T", ks.Type)
+}
+
 func (ks *Kind) String() string {
 	if ks.Type != nil && ks.Type.GetObjectKind() != nil {
 		return fmt.Sprintf("kind source: %v", ks.Type.GetObjectKind().GroupVersionKind().String())
@@ -149,14 +237,16 @@ func (ks *Kind) String() string {
 }
 
 // WaitForSync implements SyncingSource to allow controllers to wait with starting
-// workers until the cache is synced.
+// workers until the cache is synced. Start does not block on the informer, so the
+// errors that prevent it from syncing, e.g. a missing CRD, are returned here and
+// name the Kind that failed.
 func (ks *Kind) WaitForSync(ctx context.Context) error {
 	select {
 	case err := <-ks.started:
 		return err
 	case <-ctx.Done():
 		ks.startCancel()
-		return errors.New("timed out waiting for cache to be synced")
+		return fmt.Errorf("timed out waiting for cache to be synced for Kind %s", ks.kind())
 	}
 }
 
@@ -256,7 +346,7 @@ func (cs *Channel) Start(
 			}
 
 			if shouldHandle {
-				handler.Generic(evt, queue)
+				handler.Generic(ctx, evt, queue)
 			}
 		}
 	}()
@@ -307,12 +397,17 @@ func (cs *Channel) syncLoop(ctx context.Context) {
 }
 
 // Informer is used to provide a source of events originating inside the cluster from Watches (e.g. Pod Create).
+//
+// Any informer that implements the controller-runtime Informer interface can be used, including a
+// SharedIndexInformer from a client-go informer factory, so that controllers can share informers
+// managed outside of controller-runtime instead of watching the same objects twice. The informer
+// must be started by its owner, the Controller only waits for it to sync.
 type Informer struct {
 	// Informer is the controller-runtime Informer
 	Informer cache.Informer
 }
 
-var _ Source = &Informer{}
+var _ SyncingSource = &Informer{}
 
 // Start is internal and should be called only by the Controller to register an EventHandler with the Informer
 // to enqueue reconcile.Requests.
@@ -323,7 +418,20 @@ func (is *Informer) Start(ctx context.Context, handler handler.EventHandler, que
 		return fmt.Errorf("must specify Informer.Informer")
 	}
 
-	is.Informer.AddEventHandler(internal.EventHandler{Queue: queue, EventHandler: handler, Predicates: prct})
+	is.Informer.AddEventHandler(internal.EventHandler{Ctx: ctx, Queue: queue, EventHandler: handler, Predicates: prct,
+		HasSynced: is.Informer.HasSynced})
+	return nil
+}
+
+// WaitForSync implements SyncingSource to allow controllers to wait with starting
+// workers until the informer is synced.
+func (is *Informer) WaitForSync(ctx context.Context) error {
+	if is.Informer == nil {
+		return fmt.Errorf("must specify Informer.Informer")
+	}
+	if !toolscache.WaitForCacheSync(ctx.Done(), is.Informer.HasSynced) {
+		return errors.New("timed out waiting for informer to be synced")
+	}
 	return nil
 }
 
@@ -345,3 +453,137 @@ func (f Func) Start(ctx context.Context, evt handler.EventHandler, queue workque
 func (f Func) String() string {
 	return fmt.Sprintf("func source: %p", f)
 }
+
+var _ Source = &Poll{}
+
+// Poll is used to provide a source of events originating outside the cluster that can only be
+// observed by polling, e.g. to detect drift of resources in a cloud API.  Poll calls List on an
+// interval and emits a GenericEvent for each returned object.
+//
+// List is never called concurrently for the same Start call: the next interval only starts once the
+// previous call returned.  Polling stops when the context passed to Start is cancelled.
+type Poll struct {
+	// List returns the objects to emit GenericEvents for.  Errors are logged and polling continues
+	// with the next interval.
+	List func(ctx context.Context) ([]client.Object, error)
+
+	// Interval is the time between two calls of List.
+	Interval time.Duration
+
+	// JitterFactor, if greater than 0, randomly extends each interval by up to
+	// JitterFactor*Interval, so that multiple replicas or controllers polling the
+	// same system spread their requests.
+	JitterFactor float64
+}
+
+// Start is internal and should be called only by the Controller to start polling and
+// emit GenericEvents to the EventHandler.
+func (ps *Poll) Start(ctx context.Context, handler handler.EventHandler, queue workqueue.RateLimitingInterface,
+	prct ...predicate.Predicate) error {
+	if ps.List == nil {
+		return fmt.Errorf("must specify Poll.List")
+	}
+	if ps.Interval <= 0 {
+		return fmt.Errorf("must specify a positive Poll.Interval")
+	}
+
+	go wait.JitterUntilWithContext(ctx, func(ctx context.Context) {
+		objs, err := ps.List(ctx)
+		if err != nil {
+			log.Error(err, "failed to poll", "source", ps)
+			return
+		}
+		for _, obj := range objs {
+			evt := event.GenericEvent{Object: obj}
+			shouldHandle := true
+			for _, p := range prct {
+				if !p.Generic(evt) {
+					shouldHandle = false
+					break
+				}
+			}
+
+			if shouldHandle {
+				handler.Generic(ctx, evt, queue)
+			}
+		}
+	}, ps.Interval, ps.JitterFactor, true)
+
+	return nil
+}
+
+func (ps *Poll) String() string {
+	return fmt.Sprintf("poll source: %p", ps)
+}
+
+var _ Source = &TypedChannel[client.Object]{}
+
+// TypedChannel is a variant of Channel that reads TypedGenericEvents with objects of the
+// concrete type T, so that the producers of events don't need to convert them.
+type TypedChannel[T client.Object] struct {
+	// Source is the source channel to fetch TypedGenericEvents
+	Source <-chan event.TypedGenericEvent[T]
+
+	// DestBufferSize is the specified buffer size of dest channels.
+	// Default to 1024 if not specified.
+	DestBufferSize int
+
+	// once ensures Source is only converted once
+	once sync.Once
+
+	// channel distributes the converted events to all handlers
+	channel Channel
+}
+
+func (cs *TypedChannel[T]) String() string {
+	return fmt.Sprintf("typed channel source: %p", cs)
+}
+
+var _ inject.Stoppable = &TypedChannel[client.Object]{}
+
+// InjectStopChannel is internal should be called only by the Controller.
+// It is used to inject the stop channel initialized by the ControllerManager.
+func (cs *TypedChannel[T]) InjectStopChannel(stop <-chan struct{}) error {
+	return cs.channel.InjectStopChannel(stop)
+}
+
+// Start implements Source and should only be called by the Controller.
+func (cs *TypedChannel[T]) Start(
+	ctx context.Context,
+	handler handler.EventHandler,
+	queue workqueue.RateLimitingInterface,
+	prct ...predicate.Predicate) error {
+	// Source should have been specified by the user.
+	if cs.Source == nil {
+		return fmt.Errorf("must specify TypedChannel.Source")
+	}
+
+	cs.once.Do(func() {
+		src := make(chan event.GenericEvent)
+		go func() {
+			defer close(src)
+			for {
+				var evt event.TypedGenericEvent[T]
+				var ok bool
+				select {
+				case evt, ok = <-cs.Source:
+					if !ok {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+
+				select {
+				case src <- event.GenericEvent{Object: evt.Object, Metadata: evt.Metadata}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		cs.channel.Source = src
+		cs.channel.DestBufferSize = cs.DestBufferSize
+	})
+
+	return cs.channel.Start(ctx, handler, queue, prct...)
+}
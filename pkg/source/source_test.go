@@ -19,10 +19,13 @@ package source_test
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -69,21 +72,21 @@ var _ = Describe("Source", func() {
 				}
 				Expect(inject.CacheInto(ic, instance)).To(BeTrue())
 				err := instance.Start(ctx, handler.Funcs{
-					CreateFunc: func(evt event.CreateEvent, q2 workqueue.RateLimitingInterface) {
+					CreateFunc: func(_ context.Context, evt event.CreateEvent, q2 workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Expect(q2).To(Equal(q))
 						Expect(evt.Object).To(Equal(p))
 						close(c)
 					},
-					UpdateFunc: func(event.UpdateEvent, workqueue.RateLimitingInterface) {
+					UpdateFunc: func(context.Context, event.UpdateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected UpdateEvent")
 					},
-					DeleteFunc: func(event.DeleteEvent, workqueue.RateLimitingInterface) {
+					DeleteFunc: func(context.Context, event.DeleteEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected DeleteEvent")
 					},
-					GenericFunc: func(event.GenericEvent, workqueue.RateLimitingInterface) {
+					GenericFunc: func(context.Context, event.GenericEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected GenericEvent")
 					},
@@ -98,6 +101,37 @@ var _ = Describe("Source", func() {
 				<-c
 			})
 
+			It("should filter events with the Predicates of the source", func() {
+				q := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "test")
+				instance := &source.Kind{
+					Type: &corev1.Pod{},
+					Predicates: []predicate.Predicate{predicate.NewPredicateFuncs(func(o client.Object) bool {
+						return o.GetName() != "filtered"
+					})},
+				}
+				Expect(inject.CacheInto(ic, instance)).To(BeTrue())
+				created := make(chan string, 2)
+				err := instance.Start(ctx, handler.Funcs{
+					CreateFunc: func(_ context.Context, evt event.CreateEvent, _ workqueue.RateLimitingInterface) {
+						created <- evt.Object.GetName()
+					},
+				}, q)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(instance.WaitForSync(context.Background())).NotTo(HaveOccurred())
+
+				i, err := ic.FakeInformerFor(&corev1.Pod{})
+				Expect(err).NotTo(HaveOccurred())
+
+				filtered := p.DeepCopy()
+				filtered.Name = "filtered"
+				i.Add(filtered)
+				passed := p.DeepCopy()
+				passed.Name = "passed"
+				i.Add(passed)
+				Expect(<-created).To(Equal("passed"))
+				Consistently(created).ShouldNot(Receive())
+			})
+
 			It("should provide a Pod UpdateEvent", func() {
 				p2 := p.DeepCopy()
 				p2.SetLabels(map[string]string{"biz": "baz"})
@@ -109,11 +143,11 @@ var _ = Describe("Source", func() {
 				}
 				Expect(instance.InjectCache(ic)).To(Succeed())
 				err := instance.Start(ctx, handler.Funcs{
-					CreateFunc: func(evt event.CreateEvent, q2 workqueue.RateLimitingInterface) {
+					CreateFunc: func(_ context.Context, evt event.CreateEvent, q2 workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected CreateEvent")
 					},
-					UpdateFunc: func(evt event.UpdateEvent, q2 workqueue.RateLimitingInterface) {
+					UpdateFunc: func(_ context.Context, evt event.UpdateEvent, q2 workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Expect(q2).To(BeIdenticalTo(q))
 						Expect(evt.ObjectOld).To(Equal(p))
@@ -122,11 +156,11 @@ var _ = Describe("Source", func() {
 
 						close(c)
 					},
-					DeleteFunc: func(event.DeleteEvent, workqueue.RateLimitingInterface) {
+					DeleteFunc: func(context.Context, event.DeleteEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected DeleteEvent")
 					},
-					GenericFunc: func(event.GenericEvent, workqueue.RateLimitingInterface) {
+					GenericFunc: func(context.Context, event.GenericEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected GenericEvent")
 					},
@@ -157,21 +191,21 @@ var _ = Describe("Source", func() {
 				}
 				Expect(inject.CacheInto(ic, instance)).To(BeTrue())
 				err := instance.Start(ctx, handler.Funcs{
-					CreateFunc: func(event.CreateEvent, workqueue.RateLimitingInterface) {
+					CreateFunc: func(context.Context, event.CreateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected DeleteEvent")
 					},
-					UpdateFunc: func(event.UpdateEvent, workqueue.RateLimitingInterface) {
+					UpdateFunc: func(context.Context, event.UpdateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected UpdateEvent")
 					},
-					DeleteFunc: func(evt event.DeleteEvent, q2 workqueue.RateLimitingInterface) {
+					DeleteFunc: func(_ context.Context, evt event.DeleteEvent, q2 workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Expect(q2).To(BeIdenticalTo(q))
 						Expect(evt.Object).To(Equal(p))
 						close(c)
 					},
-					GenericFunc: func(event.GenericEvent, workqueue.RateLimitingInterface) {
+					GenericFunc: func(context.Context, event.GenericEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected GenericEvent")
 					},
@@ -209,8 +243,38 @@ var _ = Describe("Source", func() {
 			Expect(instance.Start(context.Background(), nil, nil)).NotTo(HaveOccurred())
 			err := instance.WaitForSync(context.Background())
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(Equal("cache did not sync"))
+			Expect(err.Error()).To(Equal("cache did not sync for Kind *v1.Pod"))
+
+		})
+
+		It("should deliver each event once if it is started again after syncing failed", func() {
+			synced := false
+			ic.Synced = &synced
+			instance := &source.Kind{Type: &corev1.Pod{}}
+			Expect(instance.InjectCache(ic)).To(Succeed())
+			q := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "test")
+			created := make(chan string, 2)
+			h := handler.Funcs{
+				CreateFunc: func(_ context.Context, evt event.CreateEvent, _ workqueue.RateLimitingInterface) {
+					created <- evt.Object.GetName()
+				},
+			}
 
+			By("failing to sync the first time")
+			Expect(instance.Start(ctx, h, q)).To(Succeed())
+			Expect(instance.WaitForSync(context.Background())).NotTo(Succeed())
+
+			By("starting again")
+			synced = true
+			Expect(instance.Start(ctx, h, q)).To(Succeed())
+			Expect(instance.WaitForSync(context.Background())).To(Succeed())
+
+			i, err := ic.FakeInformerFor(&corev1.Pod{})
+			Expect(err).NotTo(HaveOccurred())
+			p.Name = "once"
+			i.Add(p)
+			Expect(<-created).To(Equal("once"))
+			Consistently(created).ShouldNot(Receive())
 		})
 
 		Context("for a Kind not in the cache", func() {
@@ -224,7 +288,9 @@ var _ = Describe("Source", func() {
 				Expect(instance.InjectCache(ic)).To(Succeed())
 				err := instance.Start(ctx, handler.Funcs{}, q)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(instance.WaitForSync(context.Background())).To(HaveOccurred())
+				err = instance.WaitForSync(context.Background())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("failed to get informer for Kind *v1.Pod: test error"))
 			})
 		})
 	})
@@ -243,8 +309,119 @@ var _ = Describe("Source", func() {
 			Expect(instance.Start(context.Background(), nil, nil)).NotTo(HaveOccurred())
 			err := instance.WaitForSync(context.Background())
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(Equal("cache did not sync"))
+			Expect(err.Error()).To(Equal("cache did not sync for Kind *v1.Pod"))
+
+		})
+	})
+
+	Describe("Informer", func() {
+		It("should return an error from Start if an informer was not provided", func() {
+			instance := &source.Informer{}
+			err := instance.Start(ctx, &handler.EnqueueRequestForObject{}, nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return from WaitForSync once the informer is synced", func() {
+			instance := &source.Informer{Informer: &controllertest.FakeInformer{Synced: true}}
+			Expect(instance.WaitForSync(context.Background())).To(Succeed())
+		})
+
+		It("should return an error from WaitForSync if the informer does not sync", func() {
+			instance := &source.Informer{Informer: &controllertest.FakeInformer{Synced: false}}
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			Expect(instance.WaitForSync(ctx)).NotTo(Succeed())
+		})
+	})
+
+	Describe("TypedChannel", func() {
+		It("should provide GenericEvents for the typed events", func() {
+			ch := make(chan event.TypedGenericEvent[*corev1.Pod])
+			p := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"},
+			}
+
+			q := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "test")
+			instance := &source.TypedChannel[*corev1.Pod]{Source: ch}
+			Expect(inject.StopChannelInto(ctx.Done(), instance)).To(BeTrue())
+			received := make(chan event.TypedGenericEvent[*corev1.Pod], 1)
+			err := instance.Start(ctx, handler.TypedFuncs[*corev1.Pod]{
+				GenericFunc: func(_ context.Context, evt event.TypedGenericEvent[*corev1.Pod], q2 workqueue.RateLimitingInterface) {
+					defer GinkgoRecover()
+					Expect(q2).To(BeIdenticalTo(q))
+					received <- evt
+				},
+			}, q)
+			Expect(err).NotTo(HaveOccurred())
+
+			ch <- event.TypedGenericEvent[*corev1.Pod]{Object: p, Metadata: "external-id"}
+			Eventually(received).Should(Receive(Equal(event.TypedGenericEvent[*corev1.Pod]{Object: p, Metadata: "external-id"})))
+		})
+
+		It("should get error if no source specified", func() {
+			instance := &source.TypedChannel[*corev1.Pod]{}
+			Expect(instance.Start(ctx, handler.Funcs{}, nil)).To(HaveOccurred())
+		})
+	})
+
+	Describe("Poll", func() {
+		It("should emit a GenericEvent for each polled object on every interval", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			polls := make(chan struct{}, 10)
+			instance := &source.Poll{
+				Interval: 10 * time.Millisecond,
+				List: func(context.Context) ([]client.Object, error) {
+					polls <- struct{}{}
+					return []client.Object{
+						&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"}},
+						&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bar"}},
+					}, nil
+				},
+			}
+			events := make(chan string, 100)
+			q := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "test")
+			Expect(instance.Start(ctx, handler.Funcs{
+				GenericFunc: func(_ context.Context, evt event.GenericEvent, _ workqueue.RateLimitingInterface) {
+					events <- evt.Object.GetName()
+				},
+			}, q, predicate.NewPredicateFuncs(func(o client.Object) bool {
+				return o.GetName() != "bar"
+			}))).To(Succeed())
+
+			Eventually(polls).Should(Receive())
+			Eventually(polls).Should(Receive())
+			Eventually(events).Should(Receive(Equal("foo")))
+			Eventually(events).Should(Receive(Equal("foo")))
+		})
+
+		It("should stop polling once the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			polls := make(chan struct{}, 100)
+			instance := &source.Poll{
+				Interval: 10 * time.Millisecond,
+				List: func(context.Context) ([]client.Object, error) {
+					polls <- struct{}{}
+					return nil, fmt.Errorf("expected error")
+				},
+			}
+			Expect(instance.Start(ctx, handler.Funcs{}, nil)).To(Succeed())
+			Eventually(polls).Should(Receive())
+			Eventually(polls).Should(Receive())
+
+			cancel()
+			// Drain a poll that may have been in flight while cancelling.
+			time.Sleep(20 * time.Millisecond)
+			for len(polls) > 0 {
+				<-polls
+			}
+			Consistently(polls, 100*time.Millisecond).ShouldNot(Receive())
+		})
 
+		It("should return an error from Start if List or Interval are not set", func() {
+			Expect((&source.Poll{Interval: time.Second}).Start(ctx, handler.Funcs{}, nil)).NotTo(Succeed())
+			Expect((&source.Poll{List: func(context.Context) ([]client.Object, error) { return nil, nil }}).Start(ctx, handler.Funcs{}, nil)).NotTo(Succeed())
 		})
 	})
 
@@ -311,19 +488,19 @@ var _ = Describe("Source", func() {
 				instance := &source.Channel{Source: ch}
 				Expect(inject.StopChannelInto(ctx.Done(), instance)).To(BeTrue())
 				err := instance.Start(ctx, handler.Funcs{
-					CreateFunc: func(event.CreateEvent, workqueue.RateLimitingInterface) {
+					CreateFunc: func(context.Context, event.CreateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected CreateEvent")
 					},
-					UpdateFunc: func(event.UpdateEvent, workqueue.RateLimitingInterface) {
+					UpdateFunc: func(context.Context, event.UpdateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected UpdateEvent")
 					},
-					DeleteFunc: func(event.DeleteEvent, workqueue.RateLimitingInterface) {
+					DeleteFunc: func(context.Context, event.DeleteEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected DeleteEvent")
 					},
-					GenericFunc: func(evt event.GenericEvent, q2 workqueue.RateLimitingInterface) {
+					GenericFunc: func(_ context.Context, evt event.GenericEvent, q2 workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						// The empty event should have been filtered out by the predicates,
 						// and will not be passed to the handler.
@@ -351,19 +528,19 @@ var _ = Describe("Source", func() {
 				instance.DestBufferSize = 1
 				Expect(inject.StopChannelInto(ctx.Done(), instance)).To(BeTrue())
 				err := instance.Start(ctx, handler.Funcs{
-					CreateFunc: func(event.CreateEvent, workqueue.RateLimitingInterface) {
+					CreateFunc: func(context.Context, event.CreateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected CreateEvent")
 					},
-					UpdateFunc: func(event.UpdateEvent, workqueue.RateLimitingInterface) {
+					UpdateFunc: func(context.Context, event.UpdateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected UpdateEvent")
 					},
-					DeleteFunc: func(event.DeleteEvent, workqueue.RateLimitingInterface) {
+					DeleteFunc: func(context.Context, event.DeleteEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected DeleteEvent")
 					},
-					GenericFunc: func(evt event.GenericEvent, q2 workqueue.RateLimitingInterface) {
+					GenericFunc: func(_ context.Context, evt event.GenericEvent, q2 workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						// Block for the first time
 						if eventCount == 0 {
@@ -409,19 +586,19 @@ var _ = Describe("Source", func() {
 				Expect(inject.StopChannelInto(ctx.Done(), instance)).To(BeTrue())
 
 				err := instance.Start(ctx, handler.Funcs{
-					CreateFunc: func(event.CreateEvent, workqueue.RateLimitingInterface) {
+					CreateFunc: func(context.Context, event.CreateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected CreateEvent")
 					},
-					UpdateFunc: func(event.UpdateEvent, workqueue.RateLimitingInterface) {
+					UpdateFunc: func(context.Context, event.UpdateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected UpdateEvent")
 					},
-					DeleteFunc: func(event.DeleteEvent, workqueue.RateLimitingInterface) {
+					DeleteFunc: func(context.Context, event.DeleteEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected DeleteEvent")
 					},
-					GenericFunc: func(evt event.GenericEvent, q2 workqueue.RateLimitingInterface) {
+					GenericFunc: func(_ context.Context, evt event.GenericEvent, q2 workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 
 						close(processed)
@@ -451,19 +628,19 @@ var _ = Describe("Source", func() {
 				defer close(processed)
 
 				err := src.Start(ctx, handler.Funcs{
-					CreateFunc: func(event.CreateEvent, workqueue.RateLimitingInterface) {
+					CreateFunc: func(context.Context, event.CreateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected CreateEvent")
 					},
-					UpdateFunc: func(event.UpdateEvent, workqueue.RateLimitingInterface) {
+					UpdateFunc: func(context.Context, event.UpdateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected UpdateEvent")
 					},
-					DeleteFunc: func(event.DeleteEvent, workqueue.RateLimitingInterface) {
+					DeleteFunc: func(context.Context, event.DeleteEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected DeleteEvent")
 					},
-					GenericFunc: func(evt event.GenericEvent, q2 workqueue.RateLimitingInterface) {
+					GenericFunc: func(_ context.Context, evt event.GenericEvent, q2 workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 
 						processed <- struct{}{}
@@ -507,19 +684,19 @@ var _ = Describe("Source", func() {
 				instance := &source.Channel{Source: ch}
 				Expect(inject.StopChannelInto(ctx.Done(), instance)).To(BeTrue())
 				err := instance.Start(ctx, handler.Funcs{
-					CreateFunc: func(event.CreateEvent, workqueue.RateLimitingInterface) {
+					CreateFunc: func(context.Context, event.CreateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected CreateEvent")
 					},
-					UpdateFunc: func(event.UpdateEvent, workqueue.RateLimitingInterface) {
+					UpdateFunc: func(context.Context, event.UpdateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected UpdateEvent")
 					},
-					DeleteFunc: func(event.DeleteEvent, workqueue.RateLimitingInterface) {
+					DeleteFunc: func(context.Context, event.DeleteEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected DeleteEvent")
 					},
-					GenericFunc: func(evt event.GenericEvent, q2 workqueue.RateLimitingInterface) {
+					GenericFunc: func(_ context.Context, evt event.GenericEvent, q2 workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Expect(q2).To(BeIdenticalTo(q))
 						Expect(evt.Object).To(Equal(p))
@@ -530,19 +707,19 @@ var _ = Describe("Source", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				err = instance.Start(ctx, handler.Funcs{
-					CreateFunc: func(event.CreateEvent, workqueue.RateLimitingInterface) {
+					CreateFunc: func(context.Context, event.CreateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected CreateEvent")
 					},
-					UpdateFunc: func(event.UpdateEvent, workqueue.RateLimitingInterface) {
+					UpdateFunc: func(context.Context, event.UpdateEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected UpdateEvent")
 					},
-					DeleteFunc: func(event.DeleteEvent, workqueue.RateLimitingInterface) {
+					DeleteFunc: func(context.Context, event.DeleteEvent, workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Fail("Unexpected DeleteEvent")
 					},
-					GenericFunc: func(evt event.GenericEvent, q2 workqueue.RateLimitingInterface) {
+					GenericFunc: func(_ context.Context, evt event.GenericEvent, q2 workqueue.RateLimitingInterface) {
 						defer GinkgoRecover()
 						Expect(q2).To(BeIdenticalTo(q))
 						Expect(evt.Object).To(Equal(p))
@@ -28,12 +28,13 @@ import (
 // Decoder knows how to decode the contents of an admission
 // request into a concrete object.
 type Decoder struct {
+	scheme *runtime.Scheme
 	codecs serializer.CodecFactory
 }
 
 // NewDecoder creates a Decoder given the runtime.Scheme.
 func NewDecoder(scheme *runtime.Scheme) (*Decoder, error) {
-	return &Decoder{codecs: serializer.NewCodecFactory(scheme)}, nil
+	return &Decoder{scheme: scheme, codecs: serializer.NewCodecFactory(scheme)}, nil
 }
 
 // Decode decodes the inlined object in the AdmissionRequest into the passed-in runtime.Object.
@@ -70,3 +71,18 @@ func (d *Decoder) DecodeRaw(rawObj runtime.RawExtension, into runtime.Object) er
 	deserializer := d.codecs.UniversalDeserializer()
 	return runtime.DecodeInto(deserializer, rawObj.Raw, into)
 }
+
+// DecodeRawDefaulted decodes a RawExtension object into the passed-in runtime.Object
+// like DecodeRaw, and then applies the defaulting functions registered with the
+// scheme, e.g. the ones generated by defaulter-gen, to it.
+// This is useful to compare objects regardless of which fields were omitted in the request.
+// Unstructured objects are decoded without applying any defaults.
+func (d *Decoder) DecodeRawDefaulted(rawObj runtime.RawExtension, into runtime.Object) error {
+	if err := d.DecodeRaw(rawObj, into); err != nil {
+		return err
+	}
+	if _, isUnstructured := into.(*unstructured.Unstructured); !isUnstructured {
+		d.scheme.Default(into)
+	}
+	return nil
+}
@@ -125,6 +125,27 @@ var _ = Describe("Admission Webhook Decoder", func() {
 		}))
 	})
 
+	It("should apply the scheme's defaults when decoding a RawExtension object with defaulting", func() {
+		By("creating a decoder for a scheme with a defaulting func for pods")
+		s := runtime.NewScheme()
+		Expect(scheme.AddToScheme(s)).To(Succeed())
+		s.AddTypeDefaultingFunc(&corev1.Pod{}, func(obj interface{}) {
+			obj.(*corev1.Pod).Spec.RestartPolicy = corev1.RestartPolicyAlways
+		})
+		decoder, err := NewDecoder(s)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("decoding the RawExtension object")
+		var actualObj corev1.Pod
+		Expect(decoder.DecodeRawDefaulted(req.OldObject, &actualObj)).To(Succeed())
+		Expect(actualObj.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyAlways))
+
+		By("decoding the RawExtension object without defaulting")
+		var undefaultedObj corev1.Pod
+		Expect(decoder.DecodeRaw(req.OldObject, &undefaultedObj)).To(Succeed())
+		Expect(undefaultedObj.Spec.RestartPolicy).To(BeEmpty())
+	})
+
 	It("should fail to decode if the object in the request doesn't match the passed-in type", func() {
 		By("trying to extract a pod from the quest into a node")
 		Expect(decoder.Decode(req, &corev1.Node{})).NotTo(Succeed())
@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var _ = Describe("Defaulter Handler", func() {
+
+	decoder, _ := NewDecoder(scheme.Scheme)
+
+	It("should return a patch with the defaulted fields", func() {
+		handler := &defaulterForType{object: &corev1.Pod{}, defaulter: &podDefaulter{}, decoder: decoder}
+
+		resp := handler.Handle(context.TODO(), Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object: runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo","creationTimestamp":null},"spec":{"containers":null},"status":{}}`),
+				},
+			},
+		})
+		Expect(resp.Allowed).Should(BeTrue())
+		Expect(resp.Patches).To(HaveLen(1))
+		Expect(resp.Patches[0].Operation).To(Equal("add"))
+		Expect(resp.Patches[0].Path).To(Equal("/spec/restartPolicy"))
+	})
+
+	It("should deny the request if defaulting fails", func() {
+		handler := &defaulterForType{object: &corev1.Pod{}, defaulter: &podDefaulter{err: errors.New("boom")}, decoder: decoder}
+
+		resp := handler.Handle(context.TODO(), Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object: runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo"}}`),
+				},
+			},
+		})
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Reason).Should(BeEquivalentTo("boom"))
+	})
+})
+
+type podDefaulter struct {
+	err error
+}
+
+var _ CustomDefaulter = &podDefaulter{}
+
+func (d *podDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	if d.err != nil {
+		return d.err
+	}
+	obj.(*corev1.Pod).Spec.RestartPolicy = corev1.RestartPolicyNever
+	return nil
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/internal/metrics"
 )
 
 var admissionScheme = runtime.NewScheme()
@@ -51,6 +52,12 @@ func (wh *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var reviewResponse Response
+	// handled is set once the request was decoded and passed to the handler.
+	var handled bool
+	defer func() {
+		metrics.RecordAdmissionResult(r.Context(), admissionResult(reviewResponse, handled))
+	}()
+
 	if r.Body == nil {
 		err = errors.New("request body is empty")
 		wh.log.Error(err, "bad request")
@@ -76,6 +83,15 @@ func (wh *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// an empty body (e.g. http.NoBody) has no AdmissionReview to decode, reject it like a nil body
+	if len(body) == 0 {
+		err = errors.New("request body is empty")
+		wh.log.Error(err, "bad request")
+		reviewResponse = Errored(http.StatusBadRequest, err)
+		wh.writeResponse(w, reviewResponse)
+		return
+	}
+
 	// Both v1 and v1beta1 AdmissionReview types are exactly the same, so the v1beta1 type can
 	// be decoded into the v1 type. However the runtime codec's decoder guesses which type to
 	// decode into by type name if an Object's TypeMeta isn't set. By setting TypeMeta of an
@@ -96,10 +112,28 @@ func (wh *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	wh.log.V(1).Info("received request", "UID", req.UID, "kind", req.Kind, "resource", req.Resource)
 
+	handled = true
 	reviewResponse = wh.Handle(ctx, req)
 	wh.writeResponseTyped(w, reviewResponse, actualAdmRevGVK)
 }
 
+// admissionResult classifies a response as allowed, denied or errored for metrics.
+// Requests that couldn't be read or decoded are errored, as are responses of the
+// handler with a server error code.  Other responses that don't allow the request,
+// e.g. ones with a bad request code, are denied.
+func admissionResult(resp Response, handled bool) string {
+	if !handled {
+		return "errored"
+	}
+	if resp.Allowed {
+		return "allowed"
+	}
+	if resp.Result != nil && resp.Result.Code >= http.StatusInternalServerError {
+		return "errored"
+	}
+	return "denied"
+}
+
 // writeResponse writes response to w generically, i.e. without encoding GVK information.
 func (wh *Webhook) writeResponse(w io.Writer, response Response) {
 	wh.writeAdmissionResponse(w, v1.AdmissionReview{Response: &response.AdmissionResponse})
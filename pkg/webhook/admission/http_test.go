@@ -19,6 +19,7 @@ package admission
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,10 +28,12 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	admissionv1 "k8s.io/api/admission/v1"
 
 	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/internal/metrics"
 )
 
 var _ = Describe("Admission Webhooks", func() {
@@ -62,6 +65,18 @@ var _ = Describe("Admission Webhooks", func() {
 			Expect(respRecorder.Body.String()).To(Equal(expected))
 		})
 
+		It("should return bad-request when given a body without content", func() {
+			req := &http.Request{
+				Header: http.Header{"Content-Type": []string{"application/json"}},
+				Body:   http.NoBody,
+			}
+
+			expected := `{"response":{"uid":"","allowed":false,"status":{"metadata":{},"message":"request body is empty","code":400}}}
+`
+			webhook.ServeHTTP(respRecorder, req)
+			Expect(respRecorder.Body.String()).To(Equal(expected))
+		})
+
 		It("should return bad-request when given the wrong content-type", func() {
 			req := &http.Request{
 				Header: http.Header{"Content-Type": []string{"application/foo"}},
@@ -190,6 +205,40 @@ var _ = Describe("Admission Webhooks", func() {
 			webhook.ServeHTTP(respRecorder, req.WithContext(ctx))
 			Expect(respRecorder.Body.String()).To(Equal(expected))
 		})
+
+		It("should count the admission responses by result when instrumented", func() {
+			const path = "/test-admission-results"
+			response := Allowed("")
+			webhook := &Webhook{
+				Handler: &fakeHandler{
+					fn: func(ctx context.Context, req Request) Response {
+						return response
+					},
+				},
+				log: logf.RuntimeLog.WithName("webhook"),
+			}
+			hook := metrics.InstrumentedHook(path, webhook)
+			serve := func(body string) {
+				req := &http.Request{
+					Header: http.Header{"Content-Type": []string{"application/json"}},
+					Body:   nopCloser{Reader: bytes.NewBufferString(body)},
+				}
+				hook.ServeHTTP(httptest.NewRecorder(), req)
+			}
+
+			serve(`{"request":{}}`)
+			response = Denied("")
+			serve(`{"request":{}}`)
+			response = Errored(http.StatusBadRequest, errors.New("invalid object"))
+			serve(`{"request":{}}`)
+			response = Errored(http.StatusInternalServerError, errors.New("backend unavailable"))
+			serve(`{"request":{}}`)
+			serve(`{`)
+
+			Expect(testutil.ToFloat64(metrics.AdmissionResponseTotal.WithLabelValues(path, "allowed"))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(metrics.AdmissionResponseTotal.WithLabelValues(path, "denied"))).To(Equal(2.0))
+			Expect(testutil.ToFloat64(metrics.AdmissionResponseTotal.WithLabelValues(path, "errored"))).To(Equal(2.0))
+		})
 	})
 })
 
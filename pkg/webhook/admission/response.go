@@ -17,10 +17,12 @@ limitations under the License.
 package admission
 
 import (
+	goerrors "errors"
 	"net/http"
 
 	jsonpatch "gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -79,6 +81,21 @@ func ValidationResponse(allowed bool, reason string) Response {
 	return resp
 }
 
+// ValidationResponseFromError returns a response for admitting a request based on the
+// outcome of validating it: the request is allowed if err is nil, and denied otherwise.
+// If err is or wraps an API status error, e.g. one created with apierrors.NewInvalid,
+// its status (including the code and causes) is returned to the client.
+func ValidationResponseFromError(err error) Response {
+	if err == nil {
+		return Allowed("")
+	}
+	var apiStatus apierrors.APIStatus
+	if goerrors.As(err, &apiStatus) {
+		return validationResponseFromStatus(false, apiStatus.Status())
+	}
+	return Denied(err.Error())
+}
+
 // PatchResponseFromRaw takes 2 byte arrays and returns a new response with json patch.
 // The original object should be passed in as raw bytes to avoid the roundtripping problem
 // described in https://github.com/kubernetes-sigs/kubebuilder/issues/510.
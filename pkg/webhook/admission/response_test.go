@@ -18,6 +18,7 @@ package admission
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	. "github.com/onsi/ginkgo"
@@ -25,7 +26,10 @@ import (
 
 	jsonpatch "gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 var _ = Describe("Admission Webhook Response Helpers", func() {
@@ -201,6 +205,26 @@ var _ = Describe("Admission Webhook Response Helpers", func() {
 		})
 	})
 
+	Describe("ValidationResponseFromError", func() {
+		It("should return an 'allowed' response for a nil error", func() {
+			Expect(ValidationResponseFromError(nil)).To(Equal(Allowed("")))
+		})
+
+		It("should return a 'denied' response with the error as reason", func() {
+			Expect(ValidationResponseFromError(errors.New("UNACCEPTABLE!"))).To(Equal(Denied("UNACCEPTABLE!")))
+		})
+
+		It("should return the status of an API status error", func() {
+			err := apierrors.NewInvalid(schema.GroupKind{Kind: "Pod"}, "foo", field.ErrorList{
+				field.Required(field.NewPath("spec", "containers"), "at least one container is required"),
+			})
+			resp := ValidationResponseFromError(fmt.Errorf("validating: %w", err))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Code).To(Equal(int32(http.StatusUnprocessableEntity)))
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		})
+	})
+
 	Describe("PatchResponseFromRaw", func() {
 		It("should return an 'allowed' response with a patch of the diff between two sets of serialized JSON", func() {
 			expected := Response{
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var _ = Describe("Custom Validator Handler", func() {
+
+	decoder, _ := NewDecoder(scheme.Scheme)
+	podJSON := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo"}}`)
+	oldPodJSON := []byte(`{"apiVersion":"v1","kind":"Pod","metadata":{"name":"bar"}}`)
+
+	It("should pass the decoded objects to the validator", func() {
+		v := &podValidator{}
+		handler := &validatorForType{object: &corev1.Pod{}, validator: v, decoder: decoder}
+
+		resp := handler.Handle(context.TODO(), Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: podJSON},
+			},
+		})
+		Expect(resp.Allowed).Should(BeTrue())
+		Expect(resp.Result.Code).Should(Equal(int32(http.StatusOK)))
+		Expect(v.names).To(Equal([]string{"create foo"}))
+
+		resp = handler.Handle(context.TODO(), Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				Object:    runtime.RawExtension{Raw: podJSON},
+				OldObject: runtime.RawExtension{Raw: oldPodJSON},
+			},
+		})
+		Expect(resp.Allowed).Should(BeTrue())
+		Expect(v.names).To(Equal([]string{"create foo", "update bar foo"}))
+
+		resp = handler.Handle(context.TODO(), Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Delete,
+				OldObject: runtime.RawExtension{Raw: oldPodJSON},
+			},
+		})
+		Expect(resp.Allowed).Should(BeTrue())
+		Expect(v.names).To(Equal([]string{"create foo", "update bar foo", "delete bar"}))
+	})
+
+	It("should deny the request with the validator's error", func() {
+		handler := &validatorForType{object: &corev1.Pod{}, validator: &podValidator{err: errors.New("boom")}, decoder: decoder}
+
+		resp := handler.Handle(context.TODO(), Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: podJSON},
+			},
+		})
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Reason).Should(BeEquivalentTo("boom"))
+	})
+
+	It("should propagate the Status from the validator's error", func() {
+		err := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "foo", errors.New("boom"))
+		handler := &validatorForType{object: &corev1.Pod{}, validator: &podValidator{err: err}, decoder: decoder}
+
+		resp := handler.Handle(context.TODO(), Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: podJSON},
+			},
+		})
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Code).Should(Equal(int32(http.StatusForbidden)))
+	})
+
+	It("should add the validator's warnings to the response", func() {
+		handler := &validatorForType{object: &corev1.Pod{}, validator: &podValidator{warnings: Warnings{"deprecated"}}, decoder: decoder}
+
+		resp := handler.Handle(context.TODO(), Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: podJSON},
+			},
+		})
+		Expect(resp.Allowed).Should(BeTrue())
+		Expect(resp.Warnings).To(ConsistOf("deprecated"))
+
+		handler = &validatorForType{object: &corev1.Pod{}, validator: &podValidator{warnings: Warnings{"deprecated"}, err: errors.New("boom")}, decoder: decoder}
+
+		resp = handler.Handle(context.TODO(), Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: podJSON},
+			},
+		})
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Warnings).To(ConsistOf("deprecated"))
+	})
+
+	It("should return bad-request for unknown operations", func() {
+		handler := &validatorForType{object: &corev1.Pod{}, validator: &podValidator{}, decoder: decoder}
+
+		resp := handler.Handle(context.TODO(), Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Connect,
+			},
+		})
+		Expect(resp.Allowed).Should(BeFalse())
+		Expect(resp.Result.Code).Should(Equal(int32(http.StatusBadRequest)))
+	})
+})
+
+type podValidator struct {
+	names    []string
+	warnings Warnings
+	err      error
+}
+
+var _ CustomValidator = &podValidator{}
+
+func (v *podValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (Warnings, error) {
+	v.names = append(v.names, "create "+obj.(*corev1.Pod).Name)
+	return v.warnings, v.err
+}
+
+func (v *podValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (Warnings, error) {
+	v.names = append(v.names, "update "+oldObj.(*corev1.Pod).Name+" "+newObj.(*corev1.Pod).Name)
+	return v.warnings, v.err
+}
+
+func (v *podValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (Warnings, error) {
+	v.names = append(v.names, "delete "+obj.(*corev1.Pod).Name)
+	return v.warnings, v.err
+}
@@ -142,8 +142,11 @@ func (wh *Webhook) InjectLogger(l logr.Logger) error {
 // If the webhook is mutating type, it delegates the AdmissionRequest to each handler and merge the patches.
 // If the webhook is validating type, it delegates the AdmissionRequest to each handler and
 // deny the request if anyone denies.
+//
+// The request is added to the context passed to the handler, so that functions called by the
+// handler, e.g. a CustomDefaulter or CustomValidator, can retrieve it with RequestFromContext.
 func (wh *Webhook) Handle(ctx context.Context, req Request) Response {
-	resp := wh.Handler.Handle(ctx, req)
+	resp := wh.Handler.Handle(NewContextWithRequest(ctx, req), req)
 	if err := resp.Complete(req); err != nil {
 		wh.log.Error(err, "unable to encode response")
 		return Errored(http.StatusInternalServerError, errUnableToEncodeResponse)
@@ -253,3 +256,22 @@ func StandaloneWebhook(hook *Webhook, opts StandaloneOptions) (http.Handler, err
 	}
 	return metrics.InstrumentedHook(opts.MetricsPath, hook), nil
 }
+
+// requestContextKey is how we find the admission.Request in a context.Context.
+type requestContextKey struct{}
+
+// RequestFromContext returns the admission.Request stored in ctx, e.g. to check whether
+// the request is a dry-run or who sent it. It returns an error if ctx carries no request.
+func RequestFromContext(ctx context.Context) (Request, error) {
+	if v, ok := ctx.Value(requestContextKey{}).(Request); ok {
+		return v, nil
+	}
+
+	return Request{}, errors.New("admission.Request not found in context")
+}
+
+// NewContextWithRequest returns a new Context, derived from ctx, which carries the
+// provided admission.Request.
+func NewContextWithRequest(ctx context.Context, req Request) context.Context {
+	return context.WithValue(ctx, requestContextKey{}, req)
+}
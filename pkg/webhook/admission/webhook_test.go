@@ -63,6 +63,36 @@ var _ = Describe("Admission Webhooks", func() {
 		Expect(resp.Allowed).To(BeTrue())
 	})
 
+	It("should pass the request to the handler in the context", func() {
+		By("setting up a webhook with a handler reading the request from the context")
+		webhook := &Webhook{
+			Handler: HandlerFunc(func(ctx context.Context, req Request) Response {
+				ctxReq, err := RequestFromContext(ctx)
+				if err != nil {
+					return Errored(http.StatusInternalServerError, err)
+				}
+				if ctxReq.DryRun != nil && *ctxReq.DryRun {
+					return Allowed("dry run")
+				}
+				return Allowed("")
+			}),
+			log: logf.RuntimeLog.WithName("webhook"),
+		}
+
+		By("invoking the webhook with a dry-run request")
+		dryRun := true
+		resp := webhook.Handle(context.Background(), Request{AdmissionRequest: admissionv1.AdmissionRequest{DryRun: &dryRun}})
+
+		By("checking that the handler found the request")
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Result.Reason).To(Equal(metav1.StatusReason("dry run")))
+	})
+
+	It("should return an error when there is no request in the context", func() {
+		_, err := RequestFromContext(context.Background())
+		Expect(err).To(HaveOccurred())
+	})
+
 	It("should ensure that the response's UID is set to the request's UID", func() {
 		By("setting up a webhook")
 		webhook := allowHandler()
@@ -70,4 +70,8 @@ var (
 
 	// Errored indicates that an error occurred in the admission request.
 	Errored = admission.Errored
+
+	// ValidationResponseFromError indicates that the admission request should be allowed if the
+	// given error is nil, and denied with the error otherwise.
+	ValidationResponseFromError = admission.ValidationResponseFromError
 )
@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorization
+
+import (
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+// Allowed constructs a response indicating that the given action
+// is allowed.
+func Allowed(reason string) Response {
+	return ReviewResponse(true, false, reason)
+}
+
+// Denied constructs a response indicating that the given action
+// is denied. Other authorizers configured in the API server are
+// not consulted.
+func Denied(reason string) Response {
+	return ReviewResponse(false, true, reason)
+}
+
+// NoOpinion constructs a response indicating that the handler has no
+// opinion on the given action, so the API server consults the next
+// configured authorizer.
+func NoOpinion(reason string) Response {
+	return ReviewResponse(false, false, reason)
+}
+
+// Errored creates a new Response for error-handling a request.
+func Errored(err error) Response {
+	return Response{
+		SubjectAccessReview: authorizationv1.SubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{
+				Allowed:         false,
+				EvaluationError: err.Error(),
+			},
+		},
+	}
+}
+
+// ReviewResponse returns a response for authorizing a request.
+func ReviewResponse(allowed, denied bool, reason string) Response {
+	return Response{
+		SubjectAccessReview: authorizationv1.SubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{
+				Allowed: allowed,
+				Denied:  denied,
+				Reason:  reason,
+			},
+		},
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorization
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+var _ = Describe("Authorization Webhook Response Helpers", func() {
+	It("should return an 'allowed' response", func() {
+		Expect(Allowed("ok")).To(Equal(
+			Response{
+				SubjectAccessReview: authorizationv1.SubjectAccessReview{
+					Status: authorizationv1.SubjectAccessReviewStatus{
+						Allowed: true,
+						Reason:  "ok",
+					},
+				},
+			},
+		))
+	})
+
+	It("should return a 'denied' response", func() {
+		Expect(Denied("nope")).To(Equal(
+			Response{
+				SubjectAccessReview: authorizationv1.SubjectAccessReview{
+					Status: authorizationv1.SubjectAccessReviewStatus{
+						Denied: true,
+						Reason: "nope",
+					},
+				},
+			},
+		))
+	})
+
+	It("should return a response that is neither allowed nor denied for no opinion", func() {
+		Expect(NoOpinion("")).To(Equal(Response{}))
+	})
+
+	It("should return a response with an evaluation error when errored", func() {
+		Expect(Errored(errors.New("bad"))).To(Equal(
+			Response{
+				SubjectAccessReview: authorizationv1.SubjectAccessReview{
+					Status: authorizationv1.SubjectAccessReviewStatus{
+						EvaluationError: "bad",
+					},
+				},
+			},
+		))
+	})
+})
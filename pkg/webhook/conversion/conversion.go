@@ -32,19 +32,37 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
-	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	logf "sigs.k8s.io/controller-runtime/pkg/internal/log"
 )
 
 var (
-	log = logf.Log.WithName("conversion-webhook")
+	log = logf.RuntimeLog.WithName("conversion-webhook")
 )
 
 // Webhook implements a CRD conversion webhook HTTP handler.
+//
+// Objects are converted through their Hub version if the types implement
+// conversion.Hub and conversion.Convertible, otherwise the conversion functions
+// registered with the scheme are used.
 type Webhook struct {
 	scheme  *runtime.Scheme
 	decoder *Decoder
 }
 
+// NewWebhookHandler prepares a conversion webhook for use without a webhook.Server,
+// using the given scheme to decode and convert objects.
+//
+// Use this to attach the conversion webhook to an arbitrary HTTP server or mux.
+// Note that you are responsible for terminating TLS in that case, since the
+// API server only calls conversion webhooks over TLS.
+func NewWebhookHandler(scheme *runtime.Scheme) (http.Handler, error) {
+	wh := &Webhook{}
+	if err := wh.InjectScheme(scheme); err != nil {
+		return nil, err
+	}
+	return wh, nil
+}
+
 // InjectScheme injects a scheme into the webhook, in order to construct a Decoder.
 func (wh *Webhook) InjectScheme(s *runtime.Scheme) error {
 	var err error
@@ -68,6 +86,11 @@ func (wh *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
+	if convertReview.Request == nil {
+		log.Error(nil, "conversion request is nil")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 
 	// TODO(droot): may be move the conversion logic to a separate module to
 	// decouple it from the http layer ?
@@ -145,7 +168,15 @@ func (wh *Webhook) convertObject(src, dst runtime.Object) error {
 	case srcIsConvertible && dstIsConvertible:
 		return wh.convertViaHub(src.(conversion.Convertible), dst.(conversion.Convertible))
 	default:
-		return fmt.Errorf("%T is not convertible to %T", src, dst)
+		// fall back to the conversion functions registered with the scheme, e.g. ones
+		// generated by conversion-gen for types that don't implement Hub/Convertible.
+		if err := wh.scheme.Convert(src, dst, nil); err != nil {
+			return fmt.Errorf("%T is not convertible to %T: %w", src, dst, err)
+		}
+		// scheme conversion functions don't know about TypeMeta, so restore the
+		// destination's GVK that was set when allocating it.
+		dst.GetObjectKind().SetGroupVersionKind(dstGVK)
+		return nil
 	}
 }
 
@@ -156,7 +187,7 @@ func (wh *Webhook) convertViaHub(src, dst conversion.Convertible) error {
 	}
 
 	if hub == nil {
-		return fmt.Errorf("%s does not have any Hub defined", src)
+		return fmt.Errorf("%T does not have any Hub defined", src)
 	}
 
 	err = src.ConvertTo(hub)
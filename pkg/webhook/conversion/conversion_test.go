@@ -26,10 +26,12 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	appsv1 "k8s.io/api/apps/v1"
 	appsv1beta1 "k8s.io/api/apps/v1beta1"
 	apix "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/conversion"
 	"k8s.io/apimachinery/pkg/runtime"
 	kscheme "k8s.io/client-go/kubernetes/scheme"
 
@@ -226,6 +228,87 @@ var _ = Describe("Conversion Webhook", func() {
 		Expect(got).To(Equal(expected))
 	})
 
+	It("should convert using the conversion functions registered with the scheme", func() {
+		v1beta1Obj := &appsv1beta1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "apps/v1beta1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "obj-1",
+			},
+		}
+		Expect(scheme.AddConversionFunc((*appsv1beta1.Deployment)(nil), (*appsv1.Deployment)(nil), func(a, b interface{}, _ conversion.Scope) error {
+			b.(*appsv1.Deployment).ObjectMeta = a.(*appsv1beta1.Deployment).ObjectMeta
+			return nil
+		})).To(Succeed())
+
+		convReq := &apix.ConversionReview{
+			TypeMeta: metav1.TypeMeta{},
+			Request: &apix.ConversionRequest{
+				DesiredAPIVersion: "apps/v1",
+				Objects: []runtime.RawExtension{
+					{
+						Object: v1beta1Obj,
+					},
+				},
+			},
+		}
+
+		convReview := doRequest(convReq)
+
+		Expect(convReview.Response.ConvertedObjects).To(HaveLen(1))
+		Expect(convReview.Response.Result.Status).To(Equal(metav1.StatusSuccess))
+		got, _, err := decoder.Decode(convReview.Response.ConvertedObjects[0].Raw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got).To(Equal(&appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "obj-1",
+			},
+		}))
+	})
+
+	It("should convert objects with a handler created by NewWebhookHandler", func() {
+		handler, err := NewWebhookHandler(scheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		convReq := &apix.ConversionReview{
+			Request: &apix.ConversionRequest{
+				DesiredAPIVersion: "jobs.testprojects.kb.io/v2",
+				Objects: []runtime.RawExtension{
+					{
+						Object: makeV1Obj(),
+					},
+				},
+			},
+		}
+		var payload bytes.Buffer
+		Expect(json.NewEncoder(&payload).Encode(convReq)).Should(Succeed())
+
+		handler.ServeHTTP(respRecorder, &http.Request{Body: ioutil.NopCloser(&payload)})
+
+		convReview := &apix.ConversionReview{}
+		Expect(json.NewDecoder(respRecorder.Result().Body).Decode(convReview)).To(Succeed())
+		Expect(convReview.Response.Result.Status).To(Equal(metav1.StatusSuccess))
+		got, _, err := decoder.Decode(convReview.Response.ConvertedObjects[0].Raw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got).To(Equal(makeV2Obj()))
+	})
+
+	It("should return bad-request when the review has no request", func() {
+		req := &http.Request{
+			Body: ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+		}
+		webhook.ServeHTTP(respRecorder, req)
+		Expect(respRecorder.Code).To(Equal(http.StatusBadRequest))
+	})
+
 	It("should return error when dest/src objects belong to different API groups", func() {
 		v1Obj := makeV1Obj()
 
@@ -17,6 +17,7 @@ limitations under the License.
 package metrics
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -47,6 +48,19 @@ var (
 		)
 	}()
 
+	// AdmissionResponseTotal is a prometheus metric which is a counter of the admission responses
+	// by result. Admission responses are always written with HTTP status 200, so RequestTotal
+	// can't tell allowed, denied and errored requests apart.
+	AdmissionResponseTotal = func() *prometheus.CounterVec {
+		return prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "controller_runtime_webhook_admission_responses_total",
+				Help: "Total number of admission responses by result (allowed, denied or errored).",
+			},
+			[]string{"webhook", "result"},
+		)
+	}()
+
 	// RequestInFlight is a prometheus metric which is a gauge of the in-flight admission requests.
 	RequestInFlight = func() *prometheus.GaugeVec {
 		return prometheus.NewGaugeVec(
@@ -60,7 +74,7 @@ var (
 )
 
 func init() {
-	metrics.Registry.MustRegister(RequestLatency, RequestTotal, RequestInFlight)
+	metrics.Registry.MustRegister(RequestLatency, RequestTotal, RequestInFlight, AdmissionResponseTotal)
 }
 
 // InstrumentedHook adds some instrumentation on top of the given webhook.
@@ -75,11 +89,28 @@ func InstrumentedHook(path string, hookRaw http.Handler) http.Handler {
 	cnt.WithLabelValues("200")
 	cnt.WithLabelValues("500")
 
+	// Pass the path down so that admission webhooks can record their results.
+	withPath := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hookRaw.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), webhookPathKey{}, path)))
+	})
+
 	return promhttp.InstrumentHandlerDuration(
 		lat,
 		promhttp.InstrumentHandlerCounter(
 			cnt,
-			promhttp.InstrumentHandlerInFlight(gge, hookRaw),
+			promhttp.InstrumentHandlerInFlight(gge, withPath),
 		),
 	)
 }
+
+type webhookPathKey struct{}
+
+// RecordAdmissionResult counts an admission response with the given result for the
+// webhook serving the request. It's a no-op if the webhook isn't instrumented.
+func RecordAdmissionResult(ctx context.Context, result string) {
+	path, ok := ctx.Value(webhookPathKey{}).(string)
+	if !ok {
+		return
+	}
+	AdmissionResponseTotal.WithLabelValues(path, result).Inc()
+}
@@ -18,6 +18,7 @@ package webhook_test
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -114,6 +115,23 @@ var _ = Describe("Webhook Server", func() {
 		// TODO(directxman12): figure out a good way to test the port default, etc
 	})
 
+	It("should apply the TLSOpts to the serving config", func() {
+		minVersions := make(chan uint16, 1)
+		server.TLSMinVersion = "1.2"
+		server.TLSOpts = []func(*tls.Config){
+			func(cfg *tls.Config) {
+				minVersions <- cfg.MinVersion
+				cfg.CipherSuites = []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}
+			},
+		}
+		doneCh := startServer()
+
+		Eventually(minVersions).Should(Receive(Equal(uint16(tls.VersionTLS12))))
+
+		ctxCancel()
+		Eventually(doneCh, "4s").Should(BeClosed())
+	})
+
 	It("should panic if a duplicate path is registered", func() {
 		server.Register("/somepath", &testHandler{})
 		doneCh := startServer()
@@ -29,6 +29,9 @@ const (
 	// envUseEnv is an env variable that can be set to control the --use-env
 	// flag globally.
 	envUseEnv = "ENVTEST_USE_ENV"
+	// envBinDir is an env variable that can be set to control the default
+	// of the --bin-dir flag, e.g. to share a cached store between CI runs.
+	envBinDir = "ENVTEST_BIN_DIR"
 )
 
 var (
@@ -47,8 +50,8 @@ var (
 	// zapLvl is the flag value for logging verbosity.
 	zapLvl = zap.WarnLevel
 
-	binDir = flag.String("bin-dir", "",
-		"directory to store binary assets (default: $OS_SPECIFIC_DATA_DIR/envtest-binaries)")
+	binDir = flag.String("bin-dir", os.Getenv(envBinDir),
+		"directory to store binary assets (default: $"+envBinDir+" or $OS_SPECIFIC_DATA_DIR/envtest-binaries)")
 	remoteBucket = flag.String("remote-bucket", "kubebuilder-tools", "remote GCS bucket to download from")
 	remoteServer = flag.String("remote-server", "storage.googleapis.com",
 		"remote server to query from.  You can override this if you want to run "+
@@ -229,7 +232,10 @@ Environment Variables:
 	%[3]s:
 		will switch the default of --use-env to true if set to any value
 
-`, name, envNoDownload, envUseEnv)
+	%[4]s:
+		will be used as the default of --bin-dir if set
+
+`, name, envNoDownload, envUseEnv, envBinDir)
 	}
 	flag.CommandLine.AddGoFlag(&goflag.Flag{Name: "v", Usage: "logging level", Value: &zapLvl})
 	flag.VarP(&printFormat, "print", "p", "what info to print after fetch-style commands (overview, path, env)")